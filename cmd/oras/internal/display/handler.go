@@ -25,6 +25,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/content"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/descriptor"
+	"oras.land/oras/cmd/oras/internal/display/metadata/graph"
 	"oras.land/oras/cmd/oras/internal/display/metadata/json"
 	"oras.land/oras/cmd/oras/internal/display/metadata/table"
 	"oras.land/oras/cmd/oras/internal/display/metadata/template"
@@ -112,7 +113,8 @@ func NewPullHandler(printer *output.Printer, format option.Format, path string,
 }
 
 // NewDiscoverHandler returns status and metadata handlers for discover command.
-func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawReference string, desc ocispec.Descriptor, verbose bool, tty *os.File) (metadata.DiscoverHandler, error) {
+// The children are the manifests of the root index shown in graph formats.
+func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawReference string, desc ocispec.Descriptor, children []ocispec.Descriptor, verbose bool, tty *os.File) (metadata.DiscoverHandler, error) {
 	var handler metadata.DiscoverHandler
 	switch format.Type {
 	case option.FormatTypeTree.Name:
@@ -123,6 +125,44 @@ func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawRef
 		handler = json.NewDiscoverHandler(out, desc, path)
 	case option.FormatTypeGoTemplate.Name:
 		handler = template.NewDiscoverHandler(out, desc, path, format.Template)
+	case option.FormatTypeDOT.Name:
+		handler = graph.NewDOTDiscoverHandler(out, desc, children)
+	case option.FormatTypeMermaid.Name:
+		handler = graph.NewMermaidDiscoverHandler(out, desc, children)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewDiscoverRepositoryHandler returns a metadata handler for discovering
+// referrers of the tagged manifests in a repository with discover command.
+func NewDiscoverRepositoryHandler(out io.Writer, format option.Format, path string, verbose bool, tty *os.File) (metadata.DiscoverRepositoryHandler, error) {
+	var handler metadata.DiscoverRepositoryHandler
+	switch format.Type {
+	case option.FormatTypeTree.Name:
+		handler = tree.NewDiscoverRepositoryHandler(out, path, verbose, tty)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewDiscoverRepositoryHandler(out, path)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewDiscoverRepositoryHandler(out, path, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewDiscoverUpHandler returns a metadata handler for discovering the subjects
+// of a referrer with discover command.
+func NewDiscoverUpHandler(out io.Writer, format option.Format, path string, referrer ocispec.Descriptor, tty *os.File) (metadata.DiscoverUpHandler, error) {
+	var handler metadata.DiscoverUpHandler
+	switch format.Type {
+	case option.FormatTypeTree.Name:
+		handler = tree.NewDiscoverUpHandler(out, path, referrer, tty)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewDiscoverUpHandler(out, referrer, path)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewDiscoverUpHandler(out, referrer, path, format.Template)
 	default:
 		return nil, errors.UnsupportedFormatTypeError(format.Type)
 	}
@@ -182,6 +222,38 @@ func NewManifestDeleteHandler(printer *output.Printer, target *option.Target) me
 	return text.NewManifestDeleteHandler(printer, target)
 }
 
+// NewManifestDiffHandler returns a manifest diff handler.
+func NewManifestDiffHandler(printer *output.Printer, format option.Format) (metadata.ManifestDiffHandler, error) {
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		return text.NewManifestDiffHandler(printer), nil
+	case option.FormatTypeJSON.Name:
+		return json.NewManifestDiffHandler(printer), nil
+	}
+	return nil, errors.UnsupportedFormatTypeError(format.Type)
+}
+
+// NewManifestValidateHandler returns a manifest validate handler.
+func NewManifestValidateHandler(printer *output.Printer, format option.Format) (metadata.ManifestValidateHandler, error) {
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		return text.NewManifestValidateHandler(printer), nil
+	case option.FormatTypeJSON.Name:
+		return json.NewManifestValidateHandler(printer), nil
+	}
+	return nil, errors.UnsupportedFormatTypeError(format.Type)
+}
+
+// NewManifestAnnotateHandler returns a manifest annotate handler.
+func NewManifestAnnotateHandler(printer *output.Printer) metadata.ManifestAnnotateHandler {
+	return text.NewManifestAnnotateHandler(printer)
+}
+
+// NewManifestEditHandler returns a manifest edit handler.
+func NewManifestEditHandler(printer *output.Printer) metadata.ManifestEditHandler {
+	return text.NewManifestEditHandler(printer)
+}
+
 // NewManifestIndexCreateHandler returns status, metadata and content handlers for index create command.
 func NewManifestIndexCreateHandler(outputPath string, printer *output.Printer, pretty bool) (status.ManifestIndexCreateHandler, metadata.ManifestIndexCreateHandler, content.ManifestIndexCreateHandler) {
 	var statusHandler status.ManifestIndexCreateHandler
@@ -230,6 +302,13 @@ func NewCopyHandler(printer *output.Printer, tty *os.File, fetcher fetcher.Fetch
 	return status.NewTextCopyHandler(printer, fetcher), text.NewCopyHandler(printer)
 }
 
+// NewDestinationCopyHandler returns a status handler for a single destination
+// of a fan-out copy. The status is printed as text even with a TTY, as the
+// progress of multiple destinations cannot be tracked on the same terminal.
+func NewDestinationCopyHandler(printer *output.Printer, destination string, fetcher fetcher.Fetcher) status.CopyHandler {
+	return status.NewTextDestinationCopyHandler(printer, fetcher, destination)
+}
+
 // NewBackupHandler returns backup handlers.
 func NewBackupHandler(printer *output.Printer, tty *os.File, repo string, fetcher fetcher.Fetcher) (status.BackupHandler, metadata.BackupHandler) {
 	if tty != nil {
@@ -246,6 +325,29 @@ func NewRestoreHandler(printer *output.Printer, tty *os.File, fetcher fetcher.Fe
 	return status.NewTextRestoreHandler(printer, fetcher), text.NewRestoreHandler(printer, dryRun)
 }
 
+// NewBackupVerifyHandler returns a backup verify metadata handler.
+func NewBackupVerifyHandler(printer *output.Printer) metadata.BackupVerifyHandler {
+	return text.NewBackupVerifyHandler(printer)
+}
+
+// NewMirrorHandler returns a mirror metadata handler.
+func NewMirrorHandler(printer *output.Printer) metadata.MirrorHandler {
+	return text.NewMirrorHandler(printer)
+}
+
+// NewPromoteHandler returns status and metadata handlers for promote command.
+func NewPromoteHandler(printer *output.Printer, tty *os.File, fetcher fetcher.Fetcher) (status.CopyHandler, metadata.PromoteHandler) {
+	if tty != nil {
+		return status.NewTTYCopyHandler(tty), text.NewPromoteHandler(printer)
+	}
+	return status.NewTextCopyHandler(printer, fetcher), text.NewPromoteHandler(printer)
+}
+
+// NewReferrersRebaseHandler returns a referrers rebase metadata handler.
+func NewReferrersRebaseHandler(printer *output.Printer) metadata.ReferrersRebaseHandler {
+	return text.NewReferrersRebaseHandler(printer)
+}
+
 // NewBlobPushHandler returns blob push handlers.
 func NewBlobPushHandler(printer *output.Printer, outputDescriptor bool, pretty bool, desc ocispec.Descriptor, tty *os.File) (status.BlobPushHandler, metadata.BlobPushHandler) {
 	if outputDescriptor {
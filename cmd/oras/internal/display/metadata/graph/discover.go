@@ -0,0 +1,182 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/internal/descriptor"
+)
+
+// node is a node of the referrer graph.
+type node struct {
+	id    string
+	label []string
+}
+
+// edge is an edge of the referrer graph, drawn from a subject to a referrer or
+// from an index to a child manifest.
+type edge struct {
+	from  string
+	to    string
+	child bool
+}
+
+// writer writes the referrer graph in a graph description language.
+type writer func(sb *strings.Builder, nodes []node, edges []edge)
+
+// discoverHandler handles graph metadata output for discover events.
+type discoverHandler struct {
+	out   io.Writer
+	write writer
+	nodes []node
+	ids   map[digest.Digest]string
+	edges []edge
+}
+
+// NewDOTDiscoverHandler creates a new handler printing the referrer graph in
+// Graphviz DOT format. The children are the manifests of the root index, if
+// they are to be shown.
+func NewDOTDiscoverHandler(out io.Writer, root ocispec.Descriptor, children []ocispec.Descriptor) metadata.DiscoverHandler {
+	return newDiscoverHandler(out, writeDOT, root, children)
+}
+
+// NewMermaidDiscoverHandler creates a new handler printing the referrer graph
+// as a Mermaid flowchart. The children are the manifests of the root index, if
+// they are to be shown.
+func NewMermaidDiscoverHandler(out io.Writer, root ocispec.Descriptor, children []ocispec.Descriptor) metadata.DiscoverHandler {
+	return newDiscoverHandler(out, writeMermaid, root, children)
+}
+
+func newDiscoverHandler(out io.Writer, write writer, root ocispec.Descriptor, children []ocispec.Descriptor) *discoverHandler {
+	h := &discoverHandler{
+		out:   out,
+		write: write,
+		ids:   make(map[digest.Digest]string),
+	}
+	rootID := h.addNode(root)
+	for _, child := range children {
+		h.edges = append(h.edges, edge{
+			from:  rootID,
+			to:    h.addNode(child),
+			child: true,
+		})
+	}
+	return h
+}
+
+// addNode adds a node for desc if not added, and returns the node ID.
+func (h *discoverHandler) addNode(desc ocispec.Descriptor) string {
+	if id, ok := h.ids[desc.Digest]; ok {
+		return id
+	}
+	artifactType := desc.ArtifactType
+	if artifactType == "" {
+		artifactType = desc.MediaType
+	}
+	label := []string{artifactType}
+	if desc.Platform != nil {
+		label = append(label, fmt.Sprintf("%s/%s", desc.Platform.OS, desc.Platform.Architecture))
+		if desc.Platform.Variant != "" {
+			label[len(label)-1] += "/" + desc.Platform.Variant
+		}
+	}
+	// humanized sizes are padded for alignment, which is not needed in labels
+	size := strings.Join(strings.Fields(humanize.ToBytes(desc.Size).String()), " ")
+	label = append(label, descriptor.ShortDigest(desc), size)
+	id := fmt.Sprintf("n%d", len(h.nodes))
+	h.nodes = append(h.nodes, node{
+		id:    id,
+		label: label,
+	})
+	h.ids[desc.Digest] = id
+	return id
+}
+
+// OnDiscovered implements metadata.DiscoverHandler.
+func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) error {
+	subjectID, ok := h.ids[subject.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected subject descriptor: %v", subject)
+	}
+	h.edges = append(h.edges, edge{
+		from: subjectID,
+		to:   h.addNode(referrer),
+	})
+	return nil
+}
+
+// Render implements metadata.DiscoverHandler.
+func (h *discoverHandler) Render() error {
+	var sb strings.Builder
+	h.write(&sb, h.nodes, h.edges)
+	_, err := io.WriteString(h.out, sb.String())
+	return err
+}
+
+// dotEscaper escapes a string in a quoted DOT ID.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// writeDOT writes the graph in Graphviz DOT format, where the child manifests
+// of an index are connected with dashed edges.
+func writeDOT(sb *strings.Builder, nodes []node, edges []edge) {
+	sb.WriteString("digraph referrers {\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, n := range nodes {
+		label := make([]string, len(n.label))
+		for i, line := range n.label {
+			label[i] = dotEscaper.Replace(line)
+		}
+		fmt.Fprintf(sb, "  %s [label=\"%s\"];\n", n.id, strings.Join(label, `\n`))
+	}
+	for _, e := range edges {
+		if e.child {
+			fmt.Fprintf(sb, "  %s -> %s [style=dashed];\n", e.from, e.to)
+		} else {
+			fmt.Fprintf(sb, "  %s -> %s;\n", e.from, e.to)
+		}
+	}
+	sb.WriteString("}\n")
+}
+
+// mermaidEscaper escapes a string in a quoted Mermaid node label.
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+
+// writeMermaid writes the graph as a Mermaid flowchart, where the child
+// manifests of an index are connected with dotted links.
+func writeMermaid(sb *strings.Builder, nodes []node, edges []edge) {
+	sb.WriteString("flowchart TD\n")
+	for _, n := range nodes {
+		label := make([]string, len(n.label))
+		for i, line := range n.label {
+			label[i] = mermaidEscaper.Replace(line)
+		}
+		fmt.Fprintf(sb, "  %s[\"%s\"]\n", n.id, strings.Join(label, "<br/>"))
+	}
+	for _, e := range edges {
+		if e.child {
+			fmt.Fprintf(sb, "  %s -.-> %s\n", e.from, e.to)
+		} else {
+			fmt.Fprintf(sb, "  %s --> %s\n", e.from, e.to)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+)
+
+func TestDiscoverHandler(t *testing.T) {
+	root := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    "sha256:9d16f5505246424aed7116cb21216704ba8c919997d0f1f37e154c11d509e1d2",
+		Size:      2048,
+	}
+	child := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:e2c6633a79985906f1ed55c592718c73c41e809fb9818de232a635904a74d48d",
+		Size:      529,
+		Platform: &ocispec.Platform{
+			OS:           "linux",
+			Architecture: "arm64",
+			Variant:      "v8",
+		},
+	}
+	referrer := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       "sha256:2e0ca2e9df1f7bb6bd2e5aeb34ab7e5c93ed9c91a1a5f3ec86eaca0dd8a4bc21",
+		Size:         660,
+		ArtifactType: `test/"sbom"`,
+	}
+
+	tests := []struct {
+		name       string
+		newHandler func(*bytes.Buffer) metadata.DiscoverHandler
+		want       string
+	}{
+		{
+			name: "dot",
+			newHandler: func(buf *bytes.Buffer) metadata.DiscoverHandler {
+				return NewDOTDiscoverHandler(buf, root, []ocispec.Descriptor{child})
+			},
+			want: `digraph referrers {
+  node [shape=box];
+  n0 [label="application/vnd.oci.image.index.v1+json\n9d16f5505246\n2 KB"];
+  n1 [label="application/vnd.oci.image.manifest.v1+json\nlinux/arm64/v8\ne2c6633a7998\n529 B"];
+  n2 [label="test/\"sbom\"\n2e0ca2e9df1f\n660 B"];
+  n0 -> n1 [style=dashed];
+  n1 -> n2;
+}
+`,
+		},
+		{
+			name: "mermaid",
+			newHandler: func(buf *bytes.Buffer) metadata.DiscoverHandler {
+				return NewMermaidDiscoverHandler(buf, root, []ocispec.Descriptor{child})
+			},
+			want: `flowchart TD
+  n0["application/vnd.oci.image.index.v1+json<br/>9d16f5505246<br/>2 KB"]
+  n1["application/vnd.oci.image.manifest.v1+json<br/>linux/arm64/v8<br/>e2c6633a7998<br/>529 B"]
+  n2["test/#quot;sbom#quot;<br/>2e0ca2e9df1f<br/>660 B"]
+  n0 -.-> n1
+  n1 --> n2
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := tt.newHandler(&buf)
+			if err := h.OnDiscovered(referrer, child); err != nil {
+				t.Fatalf("OnDiscovered() error = %v", err)
+			}
+			if err := h.OnDiscovered(referrer, ocispec.Descriptor{Digest: "sha256:unknown"}); err == nil {
+				t.Error("OnDiscovered() should fail with an unknown subject")
+			}
+			if err := h.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,7 +19,9 @@ import (
 	"time"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/manifest"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/inventory"
 )
 
 // Renderer renders metadata information when an operation is complete.
@@ -50,6 +52,28 @@ type DiscoverHandler interface {
 	OnDiscovered(referrer, subject ocispec.Descriptor) error
 }
 
+// DiscoverRepositoryHandler handles metadata output for discovering referrers
+// of the tagged manifests in a repository.
+type DiscoverRepositoryHandler interface {
+	DiscoverHandler
+
+	// OnTagsResolved is called after the tags resolving to the same manifest
+	// are resolved, before the referrers of the manifest are discovered.
+	OnTagsResolved(tags []string, desc ocispec.Descriptor) error
+}
+
+// DiscoverUpHandler handles metadata output for discovering the subjects of a
+// referrer up to the root.
+type DiscoverUpHandler interface {
+	Renderer
+
+	// OnSubjectFound is called after the subject of a referrer is found.
+	OnSubjectFound(referrer, subject ocispec.Descriptor) error
+	// OnRootTagged is called after a tag pointing at the root is found, where
+	// desc is the root itself or an index containing the root.
+	OnRootTagged(tag string, desc ocispec.Descriptor) error
+}
+
 // ManifestFetchHandler handles metadata output for manifest fetch events.
 type ManifestFetchHandler interface {
 	// OnFetched is called after the manifest content is fetched.
@@ -89,6 +113,43 @@ type ManifestPushHandler interface {
 	OnManifestPushed(desc ocispec.Descriptor) error
 }
 
+// ManifestDiffHandler handles metadata output for manifest diff events.
+type ManifestDiffHandler interface {
+	// OnDiffed is called after the manifests referenced by from and to are
+	// diffed.
+	OnDiffed(from, to string, diff *manifest.Diff) error
+}
+
+// ManifestValidateHandler handles metadata output for manifest validate
+// events.
+type ManifestValidateHandler interface {
+	// OnValidated is called after the manifest identified by name is
+	// validated.
+	OnValidated(name string, findings manifest.Findings) error
+}
+
+// ManifestAnnotateHandler handles metadata output for manifest annotate
+// events.
+type ManifestAnnotateHandler interface {
+	TaggedHandler
+
+	// OnAnnotated is called after the manifest with updated annotations is
+	// pushed.
+	OnAnnotated(old, annotated ocispec.Descriptor) error
+	// OnUnchanged is called when the annotations are already as requested.
+	OnUnchanged(desc ocispec.Descriptor) error
+}
+
+// ManifestEditHandler handles metadata output for manifest edit events.
+type ManifestEditHandler interface {
+	TaggedHandler
+
+	// OnEdited is called after the edited manifest is pushed.
+	OnEdited(old, edited ocispec.Descriptor) error
+	// OnUnchanged is called when the edit is cancelled as nothing is changed.
+	OnUnchanged(desc ocispec.Descriptor) error
+}
+
 // ManifestIndexCreateHandler handles metadata output for index create events.
 type ManifestIndexCreateHandler interface {
 	TaggedHandler
@@ -106,6 +167,11 @@ type CopyHandler interface {
 	Renderer
 
 	OnCopied(target *option.BinaryTarget, desc ocispec.Descriptor) error
+	// OnConverted is called after a manifest is converted during copy.
+	OnConverted(source, converted ocispec.Descriptor) error
+	// OnCopyFailed is called when copying to one of multiple destinations
+	// fails.
+	OnCopyFailed(target *option.BinaryTarget, err error) error
 }
 
 // BackupHandler handles metadata output for backup events.
@@ -114,8 +180,10 @@ type BackupHandler interface {
 
 	OnTagsFound(tags []string) error
 	OnArtifactPulled(tag string, referrerCount int) error
+	OnArtifactSkipped(tag string, referrerCount int) error
 	OnTarExporting(path string) error
 	OnTarExported(path string, size int64) error
+	OnVolumesExported(manifestPath string, volumeCount int, size int64) error
 	OnBackupCompleted(tagsCount int, path string, duration time.Duration) error
 }
 
@@ -125,8 +193,55 @@ type RestoreHandler interface {
 
 	OnTarLoaded(path string, size int64) error
 	OnTagsFound(tags []string) error
+	OnTagRenamed(from, to string) error
 	OnArtifactPushed(tag string, referrerCount int) error
 	OnRestoreCompleted(tagsCount int, repo string, duration time.Duration) error
+	OnInventoryListed(path string, inv *inventory.Inventory) error
+}
+
+// BackupVerifyHandler handles metadata output for backup verify events.
+type BackupVerifyHandler interface {
+	OnTagVerified(tag string, contentCount, referrerCount int) error
+	OnTagFailed(tag string, problems []string) error
+	OnVerifyCompleted(path string, tagCount, failedCount int) error
+}
+
+// MirrorSummary summarizes the result of a mirror operation.
+type MirrorSummary struct {
+	// Mirrored is the number of tags copied to the destination.
+	Mirrored int
+	// Skipped is the number of tags skipped as unchanged since the last run.
+	Skipped int
+	// Failed is the number of tags failed to be mirrored.
+	Failed int
+	// FailedRepositories is the number of repositories failed to be
+	// mirrored as a whole, e.g. when their tags cannot be listed.
+	FailedRepositories int
+}
+
+// MirrorHandler handles metadata output for mirror events.
+type MirrorHandler interface {
+	OnTagsFound(source, destination string, tags []string) error
+	OnTagMirrored(tag string, desc ocispec.Descriptor) error
+	OnTagSkipped(tag string, desc ocispec.Descriptor) error
+	OnTagFailed(tag string, err error) error
+	OnRepositoryFailed(source string, err error) error
+	OnMirrorCompleted(summary MirrorSummary, duration time.Duration) error
+}
+
+// PromoteHandler handles metadata output for promote events.
+type PromoteHandler interface {
+	OnVerified(target *option.Target, desc ocispec.Descriptor) error
+	OnPromoted(target *option.BinaryTarget, previous, desc ocispec.Descriptor) error
+	OnUnchanged(target *option.Target, desc ocispec.Descriptor) error
+	OnRolledBack(target *option.Target, from, to ocispec.Descriptor) error
+}
+
+// ReferrersRebaseHandler handles metadata output for referrers rebase events.
+type ReferrersRebaseHandler interface {
+	OnReferrerRebased(old, rebased ocispec.Descriptor) error
+	OnReferrerDeleted(desc ocispec.Descriptor) error
+	OnRebaseCompleted(oldSubject, newSubject ocispec.Descriptor, count int) error
 }
 
 // BlobPushHandler handles metadata output for blob push events.
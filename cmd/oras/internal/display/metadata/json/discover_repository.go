@@ -0,0 +1,57 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// discoverRepositoryHandler handles json metadata output for discovering
+// referrers of the tagged manifests in a repository.
+type discoverRepositoryHandler struct {
+	out   io.Writer
+	model *model.DiscoverRepository
+}
+
+// NewDiscoverRepositoryHandler creates a new handler for discover repository
+// events.
+func NewDiscoverRepositoryHandler(out io.Writer, path string) metadata.DiscoverRepositoryHandler {
+	return &discoverRepositoryHandler{
+		out:   out,
+		model: model.NewDiscoverRepository(path),
+	}
+}
+
+// OnTagsResolved implements metadata.DiscoverRepositoryHandler.
+func (h *discoverRepositoryHandler) OnTagsResolved(tags []string, desc ocispec.Descriptor) error {
+	h.model.AddManifest(tags, desc)
+	return nil
+}
+
+// OnDiscovered implements metadata.DiscoverHandler.
+func (h *discoverRepositoryHandler) OnDiscovered(referrer, subject ocispec.Descriptor) error {
+	return h.model.AddReferrer(referrer, subject)
+}
+
+// Render implements metadata.DiscoverHandler.
+func (h *discoverRepositoryHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
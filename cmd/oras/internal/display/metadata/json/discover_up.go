@@ -0,0 +1,55 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// discoverUpHandler handles json metadata output for discover up events.
+type discoverUpHandler struct {
+	out   io.Writer
+	model *model.DiscoverUp
+}
+
+// NewDiscoverUpHandler creates a new handler for discover up events.
+func NewDiscoverUpHandler(out io.Writer, referrer ocispec.Descriptor, path string) metadata.DiscoverUpHandler {
+	return &discoverUpHandler{
+		out:   out,
+		model: model.NewDiscoverUp(path, referrer),
+	}
+}
+
+// OnSubjectFound implements metadata.DiscoverUpHandler.
+func (h *discoverUpHandler) OnSubjectFound(referrer, subject ocispec.Descriptor) error {
+	return h.model.AddSubject(referrer, subject)
+}
+
+// OnRootTagged implements metadata.DiscoverUpHandler.
+func (h *discoverUpHandler) OnRootTagged(tag string, desc ocispec.Descriptor) error {
+	h.model.AddTag(tag, desc)
+	return nil
+}
+
+// Render implements metadata.DiscoverUpHandler.
+func (h *discoverUpHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// manifestDiffHandler handles JSON metadata output for manifest diff events.
+type manifestDiffHandler struct {
+	out io.Writer
+}
+
+// NewManifestDiffHandler creates a new handler for manifest diff events.
+func NewManifestDiffHandler(out io.Writer) metadata.ManifestDiffHandler {
+	return &manifestDiffHandler{
+		out: out,
+	}
+}
+
+// OnDiffed implements metadata.ManifestDiffHandler.
+func (h *manifestDiffHandler) OnDiffed(_, _ string, diff *manifest.Diff) error {
+	return output.PrintPrettyJSON(h.out, diff)
+}
@@ -59,6 +59,45 @@ func NewDiscover(path string, root ocispec.Descriptor) Discover {
 	}
 }
 
+// DiscoverRepository is a model for discovered referrers of the tagged
+// manifests in a repository.
+type DiscoverRepository struct {
+	discover  Discover
+	Manifests []*TaggedNode `json:"manifests"`
+}
+
+// TaggedNode is a tagged manifest with its discovered referrers.
+type TaggedNode struct {
+	*Node
+	Tags []string `json:"tags"`
+}
+
+// NewDiscoverRepository creates a new discover repository model.
+func NewDiscoverRepository(path string) *DiscoverRepository {
+	return &DiscoverRepository{
+		discover: Discover{
+			name:  path,
+			nodes: map[digest.Digest]*Node{},
+		},
+		Manifests: []*TaggedNode{},
+	}
+}
+
+// AddManifest adds a manifest with the tags resolving to it.
+func (d *DiscoverRepository) AddManifest(tags []string, desc ocispec.Descriptor) {
+	node := NewNode(d.discover.name, desc)
+	d.discover.nodes[desc.Digest] = node
+	d.Manifests = append(d.Manifests, &TaggedNode{
+		Node: node,
+		Tags: tags,
+	})
+}
+
+// AddReferrer adds a referrer of a tagged manifest or of another referrer.
+func (d *DiscoverRepository) AddReferrer(referrer, subject ocispec.Descriptor) error {
+	return d.discover.AddReferrer(referrer, subject)
+}
+
 // NewNode creates a new node.
 func NewNode(name string, desc ocispec.Descriptor) *Node {
 	return &Node{
@@ -0,0 +1,69 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// DiscoverUp is a model for the subjects discovered from a referrer up to the
+// root.
+type DiscoverUp struct {
+	name string
+	// Chain is the referrer followed by its subjects, ending with the root.
+	Chain []Descriptor `json:"chain"`
+	// Root is the artifact at the end of the chain, which is not a referrer.
+	Root Descriptor `json:"root"`
+	// Tags are the tags pointing at the root.
+	Tags []RootTag `json:"tags"`
+}
+
+// RootTag is a tag pointing at the root, either directly or via an index.
+type RootTag struct {
+	Tag string `json:"tag"`
+	Descriptor
+}
+
+// NewDiscoverUp creates a new discover up model starting from the referrer.
+func NewDiscoverUp(path string, referrer ocispec.Descriptor) *DiscoverUp {
+	start := FromDescriptor(path, referrer)
+	return &DiscoverUp{
+		name:  path,
+		Chain: []Descriptor{start},
+		Root:  start,
+		Tags:  []RootTag{},
+	}
+}
+
+// AddSubject appends the subject of the last referrer in the chain.
+func (d *DiscoverUp) AddSubject(referrer, subject ocispec.Descriptor) error {
+	if referrer.Digest != d.Root.Digest {
+		return fmt.Errorf("unexpected referrer descriptor: %v", referrer)
+	}
+	d.Root = FromDescriptor(d.name, subject)
+	d.Chain = append(d.Chain, d.Root)
+	return nil
+}
+
+// AddTag adds a tag pointing at the root.
+func (d *DiscoverUp) AddTag(tag string, desc ocispec.Descriptor) {
+	d.Tags = append(d.Tags, RootTag{
+		Tag:        tag,
+		Descriptor: FromDescriptor(d.name, desc),
+	})
+}
@@ -0,0 +1,34 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "oras.land/oras/cmd/oras/internal/manifest"
+
+// Validation is a model for the validation result of a manifest.
+type Validation struct {
+	Name     string            `json:"name"`
+	Valid    bool              `json:"valid"`
+	Findings manifest.Findings `json:"findings"`
+}
+
+// NewValidation creates a new validation model.
+func NewValidation(name string, findings manifest.Findings) Validation {
+	return Validation{
+		Name:     name,
+		Valid:    findings.Count(manifest.SeverityError) == 0,
+		Findings: findings,
+	}
+}
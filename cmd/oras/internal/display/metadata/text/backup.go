@@ -48,6 +48,11 @@ func (bh *BackupHandler) OnTarExported(path string, size int64) error {
 	return bh.printer.Printf("Exported to %s (%s)\n", path, humanize.ToBytes(size))
 }
 
+// OnVolumesExported implements metadata.BackupHandler.
+func (bh *BackupHandler) OnVolumesExported(manifestPath string, volumeCount int, size int64) error {
+	return bh.printer.Printf("Exported to %d volume(s) listed in %s (%s)\n", volumeCount, manifestPath, humanize.ToBytes(size))
+}
+
 // OnTarExporting implements metadata.BackupHandler.
 func (bh *BackupHandler) OnTarExporting(path string) error {
 	return bh.printer.Printf("Exporting to %s\n", path)
@@ -59,6 +64,11 @@ func (bh *BackupHandler) OnArtifactPulled(tag string, referrerCount int) error {
 	return bh.printer.Printf("Pulled tag %s with %d referrer(s)\n", tag, referrerCount)
 }
 
+// OnArtifactSkipped implements metadata.BackupHandler.
+func (bh *BackupHandler) OnArtifactSkipped(tag string, referrerCount int) error {
+	return bh.printer.Printf("Skipped tag %s with %d referrer(s), already backed up\n", tag, referrerCount)
+}
+
 // OnTagsFound implements metadata.BackupHandler.
 func (bh *BackupHandler) OnTagsFound(tags []string) error {
 	if len(tags) == 0 {
@@ -67,6 +67,19 @@ func TestBackupHandler_OnTarExported(t *testing.T) {
 	}
 }
 
+func TestBackupHandler_OnVolumesExported(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer := output.NewPrinter(out, os.Stderr)
+	bh := NewBackupHandler("any", printer)
+	if err := bh.OnVolumesExported("test.tar.volumes.json", 3, 3*1024); err != nil {
+		t.Fatalf("OnVolumesExported() error = %v", err)
+	}
+	want := "Exported to 3 volume(s) listed in test.tar.volumes.json (3 KB)\n"
+	if got := out.String(); got != want {
+		t.Errorf("OnVolumesExported() got = %v, want %v", got, want)
+	}
+}
+
 func TestBackupHandler_OnTarExporting(t *testing.T) {
 	path := "test.tar"
 	tests := []struct {
@@ -132,6 +145,17 @@ func TestBackupHandler_OnArtifactPulled(t *testing.T) {
 	}
 }
 
+func TestBackupHandler_OnArtifactSkipped(t *testing.T) {
+	out := &bytes.Buffer{}
+	bh := NewBackupHandler("any", output.NewPrinter(out, os.Stderr))
+	if err := bh.OnArtifactSkipped("latest", 2); err != nil {
+		t.Fatalf("OnArtifactSkipped() error = %v", err)
+	}
+	if got, want := out.String(), "Skipped tag latest with 2 referrer(s), already backed up\n"; got != want {
+		t.Errorf("OnArtifactSkipped() got = %v, want %v", got, want)
+	}
+}
+
 func TestBackupHandler_OnTagsFound(t *testing.T) {
 	repo := "testRepo"
 	tests := []struct {
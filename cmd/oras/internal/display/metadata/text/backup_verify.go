@@ -0,0 +1,59 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// BackupVerifyHandler handles text metadata output for backup verify events.
+type BackupVerifyHandler struct {
+	printer *output.Printer
+}
+
+// NewBackupVerifyHandler returns a new handler for backup verify events.
+func NewBackupVerifyHandler(printer *output.Printer) metadata.BackupVerifyHandler {
+	return &BackupVerifyHandler{
+		printer: printer,
+	}
+}
+
+// OnTagVerified implements metadata.BackupVerifyHandler.
+func (bh *BackupVerifyHandler) OnTagVerified(tag string, contentCount, referrerCount int) error {
+	return bh.printer.Printf("Verified tag %s: %d content(s) with %d referrer(s)\n", tag, contentCount, referrerCount)
+}
+
+// OnTagFailed implements metadata.BackupVerifyHandler.
+func (bh *BackupVerifyHandler) OnTagFailed(tag string, problems []string) error {
+	if err := bh.printer.Printf("Failed tag %s: %d problem(s) found\n", tag, len(problems)); err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		if err := bh.printer.Println("  -", problem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnVerifyCompleted implements metadata.BackupVerifyHandler.
+func (bh *BackupVerifyHandler) OnVerifyCompleted(path string, tagCount, failedCount int) error {
+	if failedCount == 0 {
+		return bh.printer.Printf("Successfully verified %d tag(s) in %q.\n", tagCount, path)
+	}
+	return bh.printer.Printf("Verification failed for %d of %d tag(s) in %q.\n", failedCount, tagCount, path)
+}
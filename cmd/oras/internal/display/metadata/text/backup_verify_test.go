@@ -0,0 +1,70 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestBackupVerifyHandler(t *testing.T) {
+	out := &bytes.Buffer{}
+	bh := NewBackupVerifyHandler(output.NewPrinter(out, os.Stderr))
+	steps := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{
+			name: "tag verified",
+			run:  func() error { return bh.OnTagVerified("v1", 3, 2) },
+			want: "Verified tag v1: 3 content(s) with 2 referrer(s)\n",
+		},
+		{
+			name: "tag failed",
+			run: func() error {
+				return bh.OnTagFailed("v2", []string{"missing blob sha256:a", "corrupted blob sha256:b: digest mismatch"})
+			},
+			want: "Failed tag v2: 2 problem(s) found\n" +
+				"  - missing blob sha256:a\n" +
+				"  - corrupted blob sha256:b: digest mismatch\n",
+		},
+		{
+			name: "completed",
+			run:  func() error { return bh.OnVerifyCompleted("hello.tar", 2, 0) },
+			want: "Successfully verified 2 tag(s) in \"hello.tar\".\n",
+		},
+		{
+			name: "completed with failures",
+			run:  func() error { return bh.OnVerifyCompleted("hello.tar", 2, 1) },
+			want: "Verification failed for 1 of 2 tag(s) in \"hello.tar\".\n",
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			out.Reset()
+			if err := step.run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := out.String(); got != step.want {
+				t.Errorf("got %q, want %q", got, step.want)
+			}
+		})
+	}
+}
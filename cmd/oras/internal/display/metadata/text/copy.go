@@ -50,3 +50,13 @@ func (h *CopyHandler) OnCopied(target *option.BinaryTarget, desc ocispec.Descrip
 	h.desc = desc
 	return h.printer.Println("Copied", target.From.GetDisplayReference(), "=>", target.To.GetDisplayReference())
 }
+
+// OnConverted implements metadata.CopyHandler.
+func (h *CopyHandler) OnConverted(source, converted ocispec.Descriptor) error {
+	return h.printer.Printf("Converted %s %s => %s %s\n", source.MediaType, source.Digest, converted.MediaType, converted.Digest)
+}
+
+// OnCopyFailed implements metadata.CopyHandler.
+func (h *CopyHandler) OnCopyFailed(target *option.BinaryTarget, err error) error {
+	return h.printer.Printf("Failed to copy %s => %s: %v\n", target.From.GetDisplayReference(), target.To.GetDisplayReference(), err)
+}
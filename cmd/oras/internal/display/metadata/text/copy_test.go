@@ -393,3 +393,39 @@ func TestCopyHandler_Integration(t *testing.T) {
 		t.Errorf("Integration test failed.\nGot:\n%q\nWant:\n%q", got, expected)
 	}
 }
+
+func TestCopyHandler_OnConverted(t *testing.T) {
+	source := ocispec.Descriptor{
+		MediaType: "application/vnd.docker.distribution.manifest.v2+json",
+		Digest:    digest.FromString("source"),
+	}
+	converted := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("converted"),
+	}
+	out := &bytes.Buffer{}
+	handler := NewCopyHandler(output.NewPrinter(out, os.Stderr))
+	if err := handler.OnConverted(source, converted); err != nil {
+		t.Fatalf("OnConverted() error = %v", err)
+	}
+	want := fmt.Sprintf("Converted %s %s => %s %s\n", source.MediaType, source.Digest, converted.MediaType, converted.Digest)
+	if got := out.String(); got != want {
+		t.Errorf("OnConverted() got = %q, want %q", got, want)
+	}
+}
+
+func TestCopyHandler_OnCopyFailed(t *testing.T) {
+	out := &bytes.Buffer{}
+	handler := NewCopyHandler(output.NewPrinter(out, os.Stderr))
+	target := &option.BinaryTarget{
+		From: option.Target{Type: option.TargetTypeRemote, RawReference: "localhost:5000/src:v1"},
+		To:   option.Target{Type: option.TargetTypeRemote, RawReference: "localhost:6000/dst:v1"},
+	}
+	if err := handler.OnCopyFailed(target, fmt.Errorf("mocked error")); err != nil {
+		t.Fatalf("OnCopyFailed() error = %v", err)
+	}
+	want := "Failed to copy [registry] localhost:5000/src:v1 => [registry] localhost:6000/dst:v1: mocked error\n"
+	if got := out.String(); got != want {
+		t.Errorf("OnCopyFailed() got = %q, want %q", got, want)
+	}
+}
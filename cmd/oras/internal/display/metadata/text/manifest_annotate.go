@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// ManifestAnnotateHandler handles text metadata output for manifest annotate
+// events.
+type ManifestAnnotateHandler struct {
+	printer *output.Printer
+}
+
+// NewManifestAnnotateHandler returns a new handler for manifest annotate
+// events.
+func NewManifestAnnotateHandler(printer *output.Printer) metadata.ManifestAnnotateHandler {
+	return &ManifestAnnotateHandler{
+		printer: printer,
+	}
+}
+
+// OnAnnotated implements metadata.ManifestAnnotateHandler.
+func (h *ManifestAnnotateHandler) OnAnnotated(old, annotated ocispec.Descriptor) error {
+	if err := h.printer.Println("Old digest:", old.Digest); err != nil {
+		return err
+	}
+	return h.printer.Println("New digest:", annotated.Digest)
+}
+
+// OnUnchanged implements metadata.ManifestAnnotateHandler.
+func (h *ManifestAnnotateHandler) OnUnchanged(desc ocispec.Descriptor) error {
+	return h.printer.Println("Nothing to update as the annotations of", desc.Digest, "are unchanged")
+}
+
+// OnTagged implements metadata.ManifestAnnotateHandler.
+func (h *ManifestAnnotateHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	return h.printer.Println("Tagged", tag)
+}
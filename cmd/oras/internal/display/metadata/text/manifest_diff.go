@@ -0,0 +1,171 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// changeSymbols are the symbols prefixing the changes.
+var changeSymbols = map[manifest.ChangeType]string{
+	manifest.Added:   "+",
+	manifest.Removed: "-",
+	manifest.Changed: "~",
+}
+
+// ManifestDiffHandler handles text metadata output for manifest diff events.
+type ManifestDiffHandler struct {
+	printer *output.Printer
+}
+
+// NewManifestDiffHandler returns a new handler for manifest diff events.
+func NewManifestDiffHandler(printer *output.Printer) metadata.ManifestDiffHandler {
+	return &ManifestDiffHandler{
+		printer: printer,
+	}
+}
+
+// OnDiffed implements metadata.ManifestDiffHandler.
+func (h *ManifestDiffHandler) OnDiffed(from, to string, diff *manifest.Diff) error {
+	if err := h.printer.Printf("--- %s %s\n", from, diff.From.Digest); err != nil {
+		return err
+	}
+	if err := h.printer.Printf("+++ %s %s\n", to, diff.To.Digest); err != nil {
+		return err
+	}
+	if diff.Empty() {
+		return h.printer.Println("No differences found")
+	}
+
+	if c := diff.MediaType; c != nil {
+		if err := h.printer.Printf("Media type: %s -> %s\n", c.From, c.To); err != nil {
+			return err
+		}
+	}
+	if c := diff.ArtifactType; c != nil {
+		if err := h.printer.Printf("Artifact type: %s -> %s\n", valueOrNone(c.From), valueOrNone(c.To)); err != nil {
+			return err
+		}
+	}
+	if c := diff.Config; c != nil {
+		if err := h.printer.Println("Config:"); err != nil {
+			return err
+		}
+		if err := h.printDescriptorChange(*c); err != nil {
+			return err
+		}
+	}
+	if c := diff.Subject; c != nil {
+		if err := h.printer.Println("Subject:"); err != nil {
+			return err
+		}
+		if err := h.printDescriptorChange(*c); err != nil {
+			return err
+		}
+	}
+	if err := h.printDescriptorChanges("Layers:", diff.Layers); err != nil {
+		return err
+	}
+	if err := h.printDescriptorChanges("Manifests:", diff.Manifests); err != nil {
+		return err
+	}
+	if len(diff.Annotations) > 0 {
+		if err := h.printer.Println("Annotations:"); err != nil {
+			return err
+		}
+		for _, c := range diff.Annotations {
+			var err error
+			switch c.Type {
+			case manifest.Added:
+				err = h.printer.Printf("  + %s: %s\n", c.Key, c.To)
+			case manifest.Removed:
+				err = h.printer.Printf("  - %s: %s\n", c.Key, c.From)
+			default:
+				err = h.printer.Printf("  ~ %s: %s -> %s\n", c.Key, c.From, c.To)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return h.printer.Printf("Size: %s -> %s (%s)\n", humanSize(diff.FromSize), humanSize(diff.ToSize), sizeDelta(diff.SizeDelta()))
+}
+
+// printDescriptorChanges prints the changes of descriptors under the title if
+// any.
+func (h *ManifestDiffHandler) printDescriptorChanges(title string, changes []manifest.DescriptorChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := h.printer.Println(title); err != nil {
+		return err
+	}
+	for _, c := range changes {
+		if err := h.printDescriptorChange(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printDescriptorChange prints a change of a descriptor.
+func (h *ManifestDiffHandler) printDescriptorChange(c manifest.DescriptorChange) error {
+	prefix := "  " + changeSymbols[c.Type] + " "
+	if c.Name != "" {
+		prefix += c.Name + " "
+	}
+	switch c.Type {
+	case manifest.Added:
+		return h.printer.Printf("%s%s\n", prefix, describe(*c.To))
+	case manifest.Removed:
+		return h.printer.Printf("%s%s\n", prefix, describe(*c.From))
+	}
+	return h.printer.Printf("%s%s -> %s\n", prefix, describe(*c.From), describe(*c.To))
+}
+
+// describe returns the digest and the size of a descriptor.
+func describe(desc ocispec.Descriptor) string {
+	return fmt.Sprintf("%s (%s)", desc.Digest, humanSize(desc.Size))
+}
+
+// sizeDelta returns the signed human-readable size delta.
+func sizeDelta(delta int64) string {
+	if delta < 0 {
+		return "-" + humanSize(-delta)
+	}
+	return "+" + humanSize(delta)
+}
+
+// humanSize returns the human-readable size without the padding for
+// alignment.
+func humanSize(size int64) string {
+	return strings.Join(strings.Fields(humanize.ToBytes(size).String()), " ")
+}
+
+// valueOrNone returns the value, or "<none>" if it is empty.
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -0,0 +1,91 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestManifestDiffHandler_OnDiffed(t *testing.T) {
+	from := ocispec.Descriptor{Digest: digest.FromString("from")}
+	to := ocispec.Descriptor{Digest: digest.FromString("to")}
+	oldLayer := ocispec.Descriptor{Digest: digest.FromString("old"), Size: 1024}
+	newLayer := ocispec.Descriptor{Digest: digest.FromString("new"), Size: 2048}
+	out := &bytes.Buffer{}
+	h := NewManifestDiffHandler(output.NewPrinter(out, os.Stderr))
+	header := "--- localhost:5000/hello:v1 " + from.Digest.String() + "\n" +
+		"+++ localhost:5000/hello:v2 " + to.Digest.String() + "\n"
+	tests := []struct {
+		name string
+		diff *manifest.Diff
+		want string
+	}{
+		{
+			name: "no differences",
+			diff: &manifest.Diff{From: from, To: to},
+			want: header + "No differences found\n",
+		},
+		{
+			name: "differences",
+			diff: &manifest.Diff{
+				From:         from,
+				To:           to,
+				ArtifactType: &manifest.ValueChange{To: "application/vnd.test"},
+				Layers: []manifest.DescriptorChange{
+					{Type: manifest.Changed, Name: "app.bin", From: &oldLayer, To: &newLayer},
+					{Type: manifest.Removed, Name: "old.txt", From: &oldLayer},
+					{Type: manifest.Added, Name: "new.txt", To: &newLayer},
+				},
+				Annotations: []manifest.AnnotationChange{
+					{Type: manifest.Added, Key: "added", To: "value"},
+					{Type: manifest.Changed, Key: "changed", From: "v1", To: "v2"},
+					{Type: manifest.Removed, Key: "removed", From: "value"},
+				},
+				FromSize: 2048,
+				ToSize:   1024,
+			},
+			want: header +
+				"Artifact type: <none> -> application/vnd.test\n" +
+				"Layers:\n" +
+				"  ~ app.bin " + oldLayer.Digest.String() + " (1 KB) -> " + newLayer.Digest.String() + " (2 KB)\n" +
+				"  - old.txt " + oldLayer.Digest.String() + " (1 KB)\n" +
+				"  + new.txt " + newLayer.Digest.String() + " (2 KB)\n" +
+				"Annotations:\n" +
+				"  + added: value\n" +
+				"  ~ changed: v1 -> v2\n" +
+				"  - removed: value\n" +
+				"Size: 2 KB -> 1 KB (-1 KB)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out.Reset()
+			if err := h.OnDiffed("localhost:5000/hello:v1", "localhost:5000/hello:v2", tt.diff); err != nil {
+				t.Fatalf("OnDiffed() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("OnDiffed() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
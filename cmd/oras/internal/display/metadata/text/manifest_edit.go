@@ -0,0 +1,52 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// ManifestEditHandler handles text metadata output for manifest edit events.
+type ManifestEditHandler struct {
+	printer *output.Printer
+}
+
+// NewManifestEditHandler returns a new handler for manifest edit events.
+func NewManifestEditHandler(printer *output.Printer) metadata.ManifestEditHandler {
+	return &ManifestEditHandler{
+		printer: printer,
+	}
+}
+
+// OnEdited implements metadata.ManifestEditHandler.
+func (h *ManifestEditHandler) OnEdited(old, edited ocispec.Descriptor) error {
+	if err := h.printer.Println("Old digest:", old.Digest); err != nil {
+		return err
+	}
+	return h.printer.Println("New digest:", edited.Digest)
+}
+
+// OnUnchanged implements metadata.ManifestEditHandler.
+func (h *ManifestEditHandler) OnUnchanged(desc ocispec.Descriptor) error {
+	return h.printer.Println("Edit cancelled, no changes made to", desc.Digest)
+}
+
+// OnTagged implements metadata.ManifestEditHandler.
+func (h *ManifestEditHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
+	return h.printer.Println("Tagged", tag)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestManifestEditHandler(t *testing.T) {
+	old := ocispec.Descriptor{Digest: digest.FromString("old")}
+	edited := ocispec.Descriptor{Digest: digest.FromString("edited")}
+	out := &bytes.Buffer{}
+	h := NewManifestEditHandler(output.NewPrinter(out, os.Stderr))
+	if err := h.OnEdited(old, edited); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.OnTagged(edited, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.OnUnchanged(edited); err != nil {
+		t.Fatal(err)
+	}
+	want := "Old digest: " + old.Digest.String() + "\n" +
+		"New digest: " + edited.Digest.String() + "\n" +
+		"Tagged v1\n" +
+		"Edit cancelled, no changes made to " + edited.Digest.String() + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// ManifestValidateHandler handles text metadata output for manifest validate
+// events.
+type ManifestValidateHandler struct {
+	printer *output.Printer
+}
+
+// NewManifestValidateHandler returns a new handler for manifest validate
+// events.
+func NewManifestValidateHandler(printer *output.Printer) metadata.ManifestValidateHandler {
+	return &ManifestValidateHandler{
+		printer: printer,
+	}
+}
+
+// OnValidated implements metadata.ManifestValidateHandler.
+func (h *ManifestValidateHandler) OnValidated(name string, findings manifest.Findings) error {
+	for _, f := range findings {
+		message := f.Message
+		if f.Field != "" {
+			message = f.Field + ": " + message
+		}
+		if err := h.printer.Printf("%s: %s\n", f.Severity, message); err != nil {
+			return err
+		}
+		if f.Recommendation != "" {
+			if err := h.printer.Printf("  %s\n", f.Recommendation); err != nil {
+				return err
+			}
+		}
+	}
+	errorCount := findings.Count(manifest.SeverityError)
+	warningCount := findings.Count(manifest.SeverityWarning)
+	if errorCount == 0 && warningCount == 0 {
+		return h.printer.Printf("Validated %s: no problems found\n", name)
+	}
+	return h.printer.Printf("Validated %s: %d error(s), %d warning(s)\n", name, errorCount, warningCount)
+}
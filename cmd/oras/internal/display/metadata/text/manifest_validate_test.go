@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestManifestValidateHandler_OnValidated(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings manifest.Findings
+		want     string
+	}{
+		{
+			name: "no problems",
+			findings: manifest.Findings{
+				{Severity: manifest.SeverityInfo, Field: "subject", Message: "subject is not checked"},
+			},
+			want: "info: subject: subject is not checked\n" +
+				"Validated manifest.json: no problems found\n",
+		},
+		{
+			name: "problems",
+			findings: manifest.Findings{
+				{Severity: manifest.SeverityError, Message: "invalid JSON", Recommendation: "Fix the JSON."},
+				{Severity: manifest.SeverityWarning, Field: "layers", Message: "layers is empty"},
+			},
+			want: "error: invalid JSON\n" +
+				"  Fix the JSON.\n" +
+				"warning: layers: layers is empty\n" +
+				"Validated manifest.json: 1 error(s), 1 warning(s)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			h := NewManifestValidateHandler(output.NewPrinter(out, os.Stderr))
+			if err := h.OnValidated("manifest.json", tt.findings); err != nil {
+				t.Fatalf("OnValidated() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("OnValidated() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// MirrorHandler handles text metadata output for mirror events.
+type MirrorHandler struct {
+	printer *output.Printer
+}
+
+// NewMirrorHandler returns a new handler for mirror events.
+func NewMirrorHandler(printer *output.Printer) metadata.MirrorHandler {
+	return &MirrorHandler{
+		printer: printer,
+	}
+}
+
+// OnTagsFound implements metadata.MirrorHandler.
+func (mh *MirrorHandler) OnTagsFound(source, destination string, tags []string) error {
+	if len(tags) == 0 {
+		return mh.printer.Printf("Mirroring %s to %s: no matching tags found\n", source, destination)
+	}
+	return mh.printer.Printf("Mirroring %s to %s: %d tag(s) found: %s\n", source, destination, len(tags), strings.Join(tags, ", "))
+}
+
+// OnTagMirrored implements metadata.MirrorHandler.
+func (mh *MirrorHandler) OnTagMirrored(tag string, desc ocispec.Descriptor) error {
+	return mh.printer.Printf("Mirrored tag %s: %s\n", tag, desc.Digest)
+}
+
+// OnTagSkipped implements metadata.MirrorHandler.
+func (mh *MirrorHandler) OnTagSkipped(tag string, desc ocispec.Descriptor) error {
+	return mh.printer.Printf("Skipped tag %s: %s is unchanged since the last mirror\n", tag, desc.Digest)
+}
+
+// OnTagFailed implements metadata.MirrorHandler.
+func (mh *MirrorHandler) OnTagFailed(tag string, err error) error {
+	return mh.printer.Printf("Failed to mirror tag %s: %v\n", tag, err)
+}
+
+// OnRepositoryFailed implements metadata.MirrorHandler.
+func (mh *MirrorHandler) OnRepositoryFailed(source string, err error) error {
+	return mh.printer.Printf("Failed to mirror repository %s: %v\n", source, err)
+}
+
+// OnMirrorCompleted implements metadata.MirrorHandler.
+func (mh *MirrorHandler) OnMirrorCompleted(summary metadata.MirrorSummary, duration time.Duration) error {
+	if summary.FailedRepositories > 0 {
+		return mh.printer.Printf("Mirror completed in %s: %d mirrored, %d skipped, %d failed, %d repository(ies) failed\n", humanize.FormatDuration(duration), summary.Mirrored, summary.Skipped, summary.Failed, summary.FailedRepositories)
+	}
+	return mh.printer.Printf("Mirror completed in %s: %d mirrored, %d skipped, %d failed\n", humanize.FormatDuration(duration), summary.Mirrored, summary.Skipped, summary.Failed)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestMirrorHandler(t *testing.T) {
+	desc := ocispec.Descriptor{Digest: digest.FromString("foo")}
+	out := &bytes.Buffer{}
+	mh := NewMirrorHandler(output.NewPrinter(out, os.Stderr))
+	steps := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{
+			name: "no tags found",
+			run:  func() error { return mh.OnTagsFound("localhost:5000/a", "localhost:6000/a", nil) },
+			want: "Mirroring localhost:5000/a to localhost:6000/a: no matching tags found\n",
+		},
+		{
+			name: "tags found",
+			run:  func() error { return mh.OnTagsFound("localhost:5000/a", "localhost:6000/a", []string{"v1", "v2"}) },
+			want: "Mirroring localhost:5000/a to localhost:6000/a: 2 tag(s) found: v1, v2\n",
+		},
+		{
+			name: "tag mirrored",
+			run:  func() error { return mh.OnTagMirrored("v1", desc) },
+			want: "Mirrored tag v1: " + desc.Digest.String() + "\n",
+		},
+		{
+			name: "tag skipped",
+			run:  func() error { return mh.OnTagSkipped("v2", desc) },
+			want: "Skipped tag v2: " + desc.Digest.String() + " is unchanged since the last mirror\n",
+		},
+		{
+			name: "tag failed",
+			run:  func() error { return mh.OnTagFailed("v3", errors.New("boom")) },
+			want: "Failed to mirror tag v3: boom\n",
+		},
+		{
+			name: "completed",
+			run: func() error {
+				return mh.OnMirrorCompleted(metadata.MirrorSummary{Mirrored: 1, Skipped: 1, Failed: 1}, 2*time.Second)
+			},
+			want: "Mirror completed in 2s: 1 mirrored, 1 skipped, 1 failed\n",
+		},
+		{
+			name: "repository failed",
+			run:  func() error { return mh.OnRepositoryFailed("localhost:5000/b", errors.New("boom")) },
+			want: "Failed to mirror repository localhost:5000/b: boom\n",
+		},
+		{
+			name: "completed with failed repositories",
+			run: func() error {
+				return mh.OnMirrorCompleted(metadata.MirrorSummary{Mirrored: 1, FailedRepositories: 1}, 2*time.Second)
+			},
+			want: "Mirror completed in 2s: 1 mirrored, 0 skipped, 0 failed, 1 repository(ies) failed\n",
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			out.Reset()
+			if err := step.run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := out.String(); got != step.want {
+				t.Errorf("got %q, want %q", got, step.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// PromoteHandler handles text metadata output for promote events.
+type PromoteHandler struct {
+	printer *output.Printer
+}
+
+// NewPromoteHandler returns a new handler for promote events.
+func NewPromoteHandler(printer *output.Printer) metadata.PromoteHandler {
+	return &PromoteHandler{
+		printer: printer,
+	}
+}
+
+// OnVerified implements metadata.PromoteHandler.
+func (ph *PromoteHandler) OnVerified(target *option.Target, desc ocispec.Descriptor) error {
+	return ph.printer.Printf("Verified %s in %s\n", desc.Digest, target.Path)
+}
+
+// OnPromoted implements metadata.PromoteHandler.
+func (ph *PromoteHandler) OnPromoted(target *option.BinaryTarget, previous, desc ocispec.Descriptor) error {
+	if err := ph.printer.Println("Promoted", target.From.GetDisplayReference(), "=>", target.To.GetDisplayReference()); err != nil {
+		return err
+	}
+	if previous.Digest != "" {
+		if err := ph.printer.Println("Previous:", previous.Digest); err != nil {
+			return err
+		}
+	}
+	return ph.printer.Println("Digest:", desc.Digest)
+}
+
+// OnUnchanged implements metadata.PromoteHandler.
+func (ph *PromoteHandler) OnUnchanged(target *option.Target, desc ocispec.Descriptor) error {
+	return ph.printer.Printf("%s already points to %s, nothing to promote\n", target.GetDisplayReference(), desc.Digest)
+}
+
+// OnRolledBack implements metadata.PromoteHandler.
+func (ph *PromoteHandler) OnRolledBack(target *option.Target, from, to ocispec.Descriptor) error {
+	return ph.printer.Printf("Rolled back %s from %s to %s\n", target.GetDisplayReference(), from.Digest, to.Digest)
+}
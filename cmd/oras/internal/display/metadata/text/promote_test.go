@@ -0,0 +1,83 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestPromoteHandler(t *testing.T) {
+	previous := ocispec.Descriptor{Digest: digest.FromString("previous")}
+	desc := ocispec.Descriptor{Digest: digest.FromString("promoted")}
+	target := &option.BinaryTarget{
+		From: option.Target{Type: option.TargetTypeRemote, RawReference: "localhost:5000/staging/hello:v1"},
+		To:   option.Target{Type: option.TargetTypeRemote, RawReference: "localhost:6000/prod/hello:stable", Path: "localhost:6000/prod/hello"},
+	}
+	out := &bytes.Buffer{}
+	ph := NewPromoteHandler(output.NewPrinter(out, os.Stderr))
+	steps := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{
+			name: "verified",
+			run:  func() error { return ph.OnVerified(&target.To, desc) },
+			want: "Verified " + desc.Digest.String() + " in localhost:6000/prod/hello\n",
+		},
+		{
+			name: "promoted",
+			run:  func() error { return ph.OnPromoted(target, previous, desc) },
+			want: "Promoted [registry] localhost:5000/staging/hello:v1 => [registry] localhost:6000/prod/hello:stable\n" +
+				"Previous: " + previous.Digest.String() + "\n" +
+				"Digest: " + desc.Digest.String() + "\n",
+		},
+		{
+			name: "promoted to a new tag",
+			run:  func() error { return ph.OnPromoted(target, ocispec.Descriptor{}, desc) },
+			want: "Promoted [registry] localhost:5000/staging/hello:v1 => [registry] localhost:6000/prod/hello:stable\n" +
+				"Digest: " + desc.Digest.String() + "\n",
+		},
+		{
+			name: "unchanged",
+			run:  func() error { return ph.OnUnchanged(&target.To, desc) },
+			want: "[registry] localhost:6000/prod/hello:stable already points to " + desc.Digest.String() + ", nothing to promote\n",
+		},
+		{
+			name: "rolled back",
+			run:  func() error { return ph.OnRolledBack(&target.To, desc, previous) },
+			want: "Rolled back [registry] localhost:6000/prod/hello:stable from " + desc.Digest.String() + " to " + previous.Digest.String() + "\n",
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			out.Reset()
+			if err := step.run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := out.String(); got != step.want {
+				t.Errorf("got %q, want %q", got, step.want)
+			}
+		})
+	}
+}
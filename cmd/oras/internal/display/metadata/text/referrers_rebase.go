@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// ReferrersRebaseHandler handles text metadata output for referrers rebase
+// events.
+type ReferrersRebaseHandler struct {
+	printer *output.Printer
+}
+
+// NewReferrersRebaseHandler returns a new handler for referrers rebase events.
+func NewReferrersRebaseHandler(printer *output.Printer) metadata.ReferrersRebaseHandler {
+	return &ReferrersRebaseHandler{
+		printer: printer,
+	}
+}
+
+// OnReferrerRebased implements metadata.ReferrersRebaseHandler.
+func (rh *ReferrersRebaseHandler) OnReferrerRebased(old, rebased ocispec.Descriptor) error {
+	return rh.printer.Printf("Rebased %s %s => %s\n", old.ArtifactType, old.Digest, rebased.Digest)
+}
+
+// OnReferrerDeleted implements metadata.ReferrersRebaseHandler.
+func (rh *ReferrersRebaseHandler) OnReferrerDeleted(desc ocispec.Descriptor) error {
+	return rh.printer.Printf("Deleted %s %s\n", desc.ArtifactType, desc.Digest)
+}
+
+// OnRebaseCompleted implements metadata.ReferrersRebaseHandler.
+func (rh *ReferrersRebaseHandler) OnRebaseCompleted(oldSubject, newSubject ocispec.Descriptor, count int) error {
+	return rh.printer.Printf("Rebased %d referrer(s) from %s onto %s\n", count, oldSubject.Digest, newSubject.Digest)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestReferrersRebaseHandler(t *testing.T) {
+	old := ocispec.Descriptor{ArtifactType: "application/vnd.test.sbom", Digest: digest.FromString("old")}
+	rebased := ocispec.Descriptor{ArtifactType: "application/vnd.test.sbom", Digest: digest.FromString("new")}
+	out := &bytes.Buffer{}
+	rh := NewReferrersRebaseHandler(output.NewPrinter(out, os.Stderr))
+	steps := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{
+			name: "referrer rebased",
+			run:  func() error { return rh.OnReferrerRebased(old, rebased) },
+			want: "Rebased application/vnd.test.sbom " + old.Digest.String() + " => " + rebased.Digest.String() + "\n",
+		},
+		{
+			name: "referrer deleted",
+			run:  func() error { return rh.OnReferrerDeleted(old) },
+			want: "Deleted application/vnd.test.sbom " + old.Digest.String() + "\n",
+		},
+		{
+			name: "completed",
+			run:  func() error { return rh.OnRebaseCompleted(old, rebased, 2) },
+			want: "Rebased 2 referrer(s) from " + old.Digest.String() + " onto " + rebased.Digest.String() + "\n",
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			out.Reset()
+			if err := step.run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := out.String(); got != step.want {
+				t.Errorf("got %q, want %q", got, step.want)
+			}
+		})
+	}
+}
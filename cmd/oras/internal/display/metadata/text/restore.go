@@ -16,11 +16,15 @@ limitations under the License.
 package text
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/inventory"
 )
 
 // RestoreHandler handles text metadata output for restore command.
@@ -63,6 +67,14 @@ func (rh *RestoreHandler) OnTagsFound(tags []string) error {
 	return nil
 }
 
+// OnTagRenamed implements metadata.RestoreHandler.
+func (rh *RestoreHandler) OnTagRenamed(from, to string) error {
+	if rh.dryRun {
+		return rh.printer.Printf("Dry run: would restore tag %s as %s\n", from, to)
+	}
+	return rh.printer.Printf("Restoring tag %s as %s\n", from, to)
+}
+
 // OnArtifactPushed implements metadata.RestoreHandler.
 func (rh *RestoreHandler) OnArtifactPushed(tag string, referrerCount int) error {
 	if rh.dryRun {
@@ -71,6 +83,35 @@ func (rh *RestoreHandler) OnArtifactPushed(tag string, referrerCount int) error
 	return rh.printer.Printf("Pushed tag %s with %d referrer(s)\n", tag, referrerCount)
 }
 
+// OnInventoryListed implements metadata.RestoreHandler.
+func (rh *RestoreHandler) OnInventoryListed(path string, inv *inventory.Inventory) error {
+	if err := rh.printer.Printf("Backup: %s\nSource: %s\nCreated: %s\nORAS version: %s\nSize: %s\nTags: %d\n",
+		path, inv.Source, inv.Created.Format(time.RFC3339), inv.OrasVersion, humanize.ToBytes(inv.Size), len(inv.Tags)); err != nil {
+		return err
+	}
+	for _, tag := range inv.Tags {
+		if err := rh.printer.Printf("- %s\n    Digest: %s\n    Media type: %s\n", tag.Name, tag.Digest, tag.MediaType); err != nil {
+			return err
+		}
+		if len(tag.Platforms) > 0 {
+			if err := rh.printer.Printf("    Platforms: %s\n", strings.Join(tag.Platforms, ", ")); err != nil {
+				return err
+			}
+		}
+		if len(tag.Referrers) > 0 {
+			// list the referrers by artifact type in a stable order
+			referrers := make([]string, 0, len(tag.Referrers))
+			for _, artifactType := range slices.Sorted(maps.Keys(tag.Referrers)) {
+				referrers = append(referrers, fmt.Sprintf("%s (%d)", artifactType, tag.Referrers[artifactType]))
+			}
+			if err := rh.printer.Printf("    Referrers: %s\n", strings.Join(referrers, ", ")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // OnRestoreCompleted implements metadata.RestoreHandler.
 func (rh *RestoreHandler) OnRestoreCompleted(tagsCount int, repo string, duration time.Duration) error {
 	if rh.dryRun {
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/inventory"
 )
 
 // TestNewRestoreHandler tests the constructor for RestoreHandler
@@ -162,6 +163,37 @@ func TestRestoreHandler_OnTagsFound(t *testing.T) {
 	}
 }
 
+func TestRestoreHandler_OnTagRenamed(t *testing.T) {
+	tests := []struct {
+		name   string
+		dryRun bool
+		want   string
+	}{
+		{
+			name:   "normal restore",
+			dryRun: false,
+			want:   "Restoring tag v1 as v1-dr\n",
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want:   "Dry run: would restore tag v1 as v1-dr\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			handler := NewRestoreHandler(output.NewPrinter(out, os.Stderr), tt.dryRun)
+			if err := handler.OnTagRenamed("v1", "v1-dr"); err != nil {
+				t.Fatalf("OnTagRenamed() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("OnTagRenamed() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRestoreHandler_OnArtifactPushed(t *testing.T) {
 	tag := "latest"
 	referrerCount := 2
@@ -269,3 +301,52 @@ func TestRestoreHandler_Render(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreHandler_OnInventoryListed(t *testing.T) {
+	inv := &inventory.Inventory{
+		Source:      "localhost:5000/hello",
+		Created:     time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC),
+		OrasVersion: "1.3.0",
+		Size:        2048,
+		Tags: []inventory.Tag{
+			{
+				Name:      "v1",
+				Digest:    "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+				MediaType: "application/vnd.oci.image.index.v1+json",
+				Platforms: []string{"linux/amd64", "linux/arm64"},
+				Referrers: map[string]int{
+					"application/vnd.test.signature": 2,
+					"application/vnd.test.sbom":      1,
+				},
+			},
+			{
+				Name:      "v2",
+				Digest:    "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+				MediaType: "application/vnd.oci.image.manifest.v1+json",
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	handler := NewRestoreHandler(output.NewPrinter(out, os.Stderr), false)
+	if err := handler.OnInventoryListed("hello.tar", inv); err != nil {
+		t.Fatalf("OnInventoryListed() error = %v", err)
+	}
+	want := `Backup: hello.tar
+Source: localhost:5000/hello
+Created: 2025-06-30T12:00:00Z
+ORAS version: 1.3.0
+Size: 2 KB
+Tags: 2
+- v1
+    Digest: sha256:1111111111111111111111111111111111111111111111111111111111111111
+    Media type: application/vnd.oci.image.index.v1+json
+    Platforms: linux/amd64, linux/arm64
+    Referrers: application/vnd.test.sbom (1), application/vnd.test.signature (2)
+- v2
+    Digest: sha256:2222222222222222222222222222222222222222222222222222222222222222
+    Media type: application/vnd.oci.image.manifest.v1+json
+`
+	if got := out.String(); got != want {
+		t.Errorf("OnInventoryListed() got = %q, want %q", got, want)
+	}
+}
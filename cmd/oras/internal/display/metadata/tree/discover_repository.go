@@ -0,0 +1,64 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/internal/tree"
+)
+
+// discoverRepositoryHandler handles tree metadata output for discovering
+// referrers of the tagged manifests in a repository.
+type discoverRepositoryHandler struct {
+	*discoverHandler
+}
+
+// NewDiscoverRepositoryHandler creates a new handler for discover repository
+// events.
+func NewDiscoverRepositoryHandler(out io.Writer, path string, verbose bool, tty *os.File) metadata.DiscoverRepositoryHandler {
+	root := path
+	if tty != nil {
+		root = digestColor.Apply(root)
+	}
+	return &discoverRepositoryHandler{
+		discoverHandler: &discoverHandler{
+			out:     out,
+			path:    path,
+			root:    tree.New(root),
+			nodes:   map[digest.Digest]*tree.Node{},
+			verbose: verbose,
+			tty:     tty,
+		},
+	}
+}
+
+// OnTagsResolved implements metadata.DiscoverRepositoryHandler.
+func (h *discoverRepositoryHandler) OnTagsResolved(tags []string, desc ocispec.Descriptor) error {
+	tagList := strings.Join(tags, ", ")
+	dgst := desc.Digest.String()
+	if h.tty != nil {
+		tagList = tagColor.Apply(tagList)
+		dgst = digestColor.Apply(dgst)
+	}
+	h.nodes[desc.Digest] = h.root.AddPath(tagList, dgst)
+	return nil
+}
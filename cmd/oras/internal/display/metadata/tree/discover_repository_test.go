@@ -0,0 +1,60 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestDiscoverRepositoryHandler(t *testing.T) {
+	image := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("image"),
+	}
+	signature := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       digest.FromString("signature"),
+		ArtifactType: "application/vnd.test.signature",
+	}
+
+	var buf bytes.Buffer
+	h := NewDiscoverRepositoryHandler(&buf, "localhost:5000/test", false, nil)
+	if err := h.OnDiscovered(signature, image); err == nil {
+		t.Error("OnDiscovered() should fail before the tags of the subject are resolved")
+	}
+	if err := h.OnTagsResolved([]string{"latest", "v1"}, image); err != nil {
+		t.Fatalf("OnTagsResolved() error = %v", err)
+	}
+	if err := h.OnDiscovered(signature, image); err != nil {
+		t.Fatalf("OnDiscovered() error = %v", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "localhost:5000/test\n" +
+		"└── latest, v1\n" +
+		"    └── " + image.Digest.String() + "\n" +
+		"        └── application/vnd.test.signature\n" +
+		"            └── " + signature.Digest.String() + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/morikuni/aec"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/internal/tree"
+)
+
+var tagColor = aec.LightCyanF
+
+// discoverUpHandler handles tree metadata output for discover up events.
+type discoverUpHandler struct {
+	out      io.Writer
+	root     *tree.Node
+	last     *tree.Node
+	lastDgst digest.Digest
+	tags     *tree.Node
+	tty      *os.File
+}
+
+// NewDiscoverUpHandler creates a new handler for discover up events.
+func NewDiscoverUpHandler(out io.Writer, path string, referrer ocispec.Descriptor, tty *os.File) metadata.DiscoverUpHandler {
+	start := fmt.Sprintf("%s@%s", path, referrer.Digest)
+	if tty != nil {
+		start = digestColor.Apply(start)
+	}
+	treeRoot := tree.New(start)
+	return &discoverUpHandler{
+		out:      out,
+		root:     treeRoot,
+		last:     treeRoot,
+		lastDgst: referrer.Digest,
+		tty:      tty,
+	}
+}
+
+// OnSubjectFound implements metadata.DiscoverUpHandler.
+func (h *discoverUpHandler) OnSubjectFound(referrer, subject ocispec.Descriptor) error {
+	if referrer.Digest != h.lastDgst {
+		return fmt.Errorf("unexpected referrer descriptor: %v", referrer)
+	}
+
+	// add the artifact type, or the media type of an artifact without
+	// artifact type, and the digest of the subject
+	artifactType := subject.ArtifactType
+	if artifactType == "" {
+		artifactType = subject.MediaType
+	}
+	dgst := subject.Digest.String()
+	title := h.title("[subject]")
+	if h.tty != nil {
+		artifactType = artifactTypeColor.Apply(artifactType)
+		dgst = digestColor.Apply(dgst)
+	}
+	h.last = h.last.AddPath(title+" "+artifactType, dgst)
+	h.lastDgst = subject.Digest
+	return nil
+}
+
+// OnRootTagged implements metadata.DiscoverUpHandler.
+func (h *discoverUpHandler) OnRootTagged(tag string, desc ocispec.Descriptor) error {
+	if h.tags == nil {
+		h.tags = h.last.Add(h.title("[tags]"))
+	}
+	if h.tty != nil {
+		tag = tagColor.Apply(tag)
+	}
+	if desc.Digest != h.lastDgst {
+		// the tag points at an index containing the root
+		tag = fmt.Sprintf("%s (index %s)", tag, desc.Digest)
+	}
+	h.tags.Add(tag)
+	return nil
+}
+
+// title returns the title of a node, colored if tty is set.
+func (h *discoverUpHandler) title(title string) string {
+	if h.tty != nil {
+		return annotationsColor.Apply(title)
+	}
+	return title
+}
+
+// Render implements metadata.DiscoverUpHandler.
+func (h *discoverUpHandler) Render() error {
+	return tree.NewPrinter(h.out).Print(h.root)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestDiscoverUpHandler(t *testing.T) {
+	scan := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		Digest:       digest.FromString("scan"),
+		ArtifactType: "application/vnd.test.scan",
+	}
+	image := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("image"),
+	}
+	index := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Digest:    digest.FromString("index"),
+	}
+
+	var buf bytes.Buffer
+	h := NewDiscoverUpHandler(&buf, "localhost:5000/test", scan, nil)
+	if err := h.OnSubjectFound(scan, image); err != nil {
+		t.Fatalf("OnSubjectFound() error = %v", err)
+	}
+	if err := h.OnSubjectFound(scan, image); err == nil {
+		t.Error("OnSubjectFound() should fail when the referrer is not the last subject")
+	}
+	if err := h.OnRootTagged("v1", image); err != nil {
+		t.Fatalf("OnRootTagged() error = %v", err)
+	}
+	if err := h.OnRootTagged("latest", index); err != nil {
+		t.Fatalf("OnRootTagged() error = %v", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "localhost:5000/test@" + scan.Digest.String() + "\n" +
+		"└── [subject] " + ocispec.MediaTypeImageManifest + "\n" +
+		"    └── " + image.Digest.String() + "\n" +
+		"        └── [tags]\n" +
+		"            ├── v1\n" +
+		"            └── latest (index " + index.Digest.String() + ")\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
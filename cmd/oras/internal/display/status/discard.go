@@ -164,3 +164,8 @@ func (DiscardHandler) StartTracking(gt oras.GraphTarget) (oras.GraphTarget, erro
 func (DiscardHandler) StopTracking() error {
 	return nil
 }
+
+// OnMounted implements CopyHandler.
+func (DiscardHandler) OnMounted(_ context.Context, _ ocispec.Descriptor) error {
+	return nil
+}
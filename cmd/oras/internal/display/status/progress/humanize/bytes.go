@@ -18,6 +18,8 @@ package humanize
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 )
 
 const base = 1024.0
@@ -58,3 +60,35 @@ func RoundTo(size float64) float64 {
 	}
 	return math.Round(size)
 }
+
+// ParseBytes parses a human readable size, such as "512MB", "4GiB" or "1.5 TB",
+// into bytes. Units are case-insensitive and in the base of 1024, the same as
+// ToBytes. A size without unit is in bytes.
+func ParseBytes(s string) (int64, error) {
+	trimmed := strings.TrimSpace(s)
+	i := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	number, unit := trimmed, ""
+	if i >= 0 {
+		number, unit = trimmed[:i], strings.TrimSpace(trimmed[i:])
+	}
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	unit = strings.TrimSuffix(strings.ToUpper(unit), "IB")
+	unit = strings.TrimSuffix(unit, "B")
+	var e int
+	if unit != "" {
+		e = strings.Index("KMGT", unit) + 1
+		if len(unit) != 1 || e == 0 {
+			return 0, fmt.Errorf("invalid unit of size %q", s)
+		}
+	}
+	bytes := size * math.Pow(base, float64(e))
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+	return int64(bytes), nil
+}
@@ -70,3 +70,37 @@ func TestToBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{input: "100", want: 100},
+		{input: "100B", want: 100},
+		{input: "2K", want: 2048},
+		{input: "2KB", want: 2048},
+		{input: "2kib", want: 2048},
+		{input: "1.5 MiB", want: 1572864},
+		{input: "4GiB", want: 4 << 30},
+		{input: "1TB", want: 1 << 40},
+		{input: "", wantErr: true},
+		{input: "GB", wantErr: true},
+		{input: "1PB", wantErr: true},
+		{input: "1KBB", wantErr: true},
+		{input: "1.2.3MB", wantErr: true},
+		{input: "99999999999TB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseBytes(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBytes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -140,6 +140,9 @@ type TextCopyHandler struct {
 	printer   *output.Printer
 	committed *sync.Map
 	fetcher   content.Fetcher
+	// prefix is prepended to each prompt, e.g. to tell the destinations of a
+	// fan-out copy apart.
+	prefix string
 }
 
 // NewTextCopyHandler returns a new handler for push command.
@@ -151,6 +154,17 @@ func NewTextCopyHandler(printer *output.Printer, fetcher content.Fetcher) CopyHa
 	}
 }
 
+// NewTextDestinationCopyHandler returns a new handler for a single destination
+// of a fan-out copy. The status is prefixed with the destination.
+func NewTextDestinationCopyHandler(printer *output.Printer, fetcher content.Fetcher, destination string) CopyHandler {
+	return &TextCopyHandler{
+		printer:   printer,
+		fetcher:   fetcher,
+		committed: &sync.Map{},
+		prefix:    "[" + destination + "] ",
+	}
+}
+
 // StartTracking starts a tracked target from a graph target.
 func (ch *TextCopyHandler) StartTracking(gt oras.GraphTarget) (oras.GraphTarget, error) {
 	return gt, nil
@@ -164,12 +178,12 @@ func (ch *TextCopyHandler) StopTracking() error {
 // OnCopySkipped is called when an object already exists.
 func (ch *TextCopyHandler) OnCopySkipped(_ context.Context, desc ocispec.Descriptor) error {
 	ch.committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
-	return ch.printer.PrintStatus(desc, copyPromptExists)
+	return ch.printer.PrintStatus(desc, ch.prefix+copyPromptExists)
 }
 
 // PreCopy implements PreCopy of CopyHandler.
 func (ch *TextCopyHandler) PreCopy(_ context.Context, desc ocispec.Descriptor) error {
-	return ch.printer.PrintStatus(desc, copyPromptCopying)
+	return ch.printer.PrintStatus(desc, ch.prefix+copyPromptCopying)
 }
 
 // PostCopy implements PostCopy of CopyHandler.
@@ -180,17 +194,17 @@ func (ch *TextCopyHandler) PostCopy(ctx context.Context, desc ocispec.Descriptor
 		return err
 	}
 	for _, successor := range deduplicated {
-		if err = ch.printer.PrintStatus(successor, copyPromptSkipped); err != nil {
+		if err = ch.printer.PrintStatus(successor, ch.prefix+copyPromptSkipped); err != nil {
 			return err
 		}
 	}
-	return ch.printer.PrintStatus(desc, copyPromptCopied)
+	return ch.printer.PrintStatus(desc, ch.prefix+copyPromptCopied)
 }
 
 // OnMounted implements OnMounted of CopyHandler.
 func (ch *TextCopyHandler) OnMounted(_ context.Context, desc ocispec.Descriptor) error {
 	ch.committed.Store(desc.Digest.String(), desc.Annotations[ocispec.AnnotationTitle])
-	return ch.printer.PrintStatus(desc, copyPromptMounted)
+	return ch.printer.PrintStatus(desc, ch.prefix+copyPromptMounted)
 }
 
 // TextBackupHandler handles text status output for backup events.
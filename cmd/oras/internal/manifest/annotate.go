@@ -0,0 +1,73 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrAnnotationNotFound is returned when an annotation to remove is not found.
+var ErrAnnotationNotFound = errors.New("annotation not found")
+
+// Annotate sets the annotations in set and removes the annotations in remove
+// from a manifest or an index. Other fields are kept as is, and the
+// annotations field is dropped if no annotation is left. The manifest is
+// returned unmodified if the annotations are already as requested.
+func Annotate(manifest []byte, set map[string]string, remove []string) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(manifest, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	annotations := map[string]string{}
+	if raw, ok := fields["annotations"]; ok {
+		if err := json.Unmarshal(raw, &annotations); err != nil {
+			return nil, fmt.Errorf("failed to parse annotations: %w", err)
+		}
+		if annotations == nil {
+			// annotations is null
+			annotations = map[string]string{}
+		}
+	}
+	changed := len(remove) > 0
+	for _, key := range remove {
+		if _, ok := annotations[key]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrAnnotationNotFound, key)
+		}
+		delete(annotations, key)
+	}
+	for key, value := range set {
+		if current, ok := annotations[key]; !ok || current != value {
+			annotations[key] = value
+			changed = true
+		}
+	}
+	if !changed {
+		return manifest, nil
+	}
+
+	if len(annotations) == 0 {
+		delete(fields, "annotations")
+	} else {
+		encoded, err := json.Marshal(annotations)
+		if err != nil {
+			return nil, err
+		}
+		fields["annotations"] = encoded
+	}
+	return json.Marshal(fields)
+}
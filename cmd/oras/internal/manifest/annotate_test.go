@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAnnotate(t *testing.T) {
+	const manifest = `{"schemaVersion":2,"annotations":{"keep":"value","typo":"value"}}`
+	tests := []struct {
+		name     string
+		manifest string
+		set      map[string]string
+		remove   []string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "set and remove",
+			manifest: manifest,
+			set:      map[string]string{"new": "value", "keep": "updated"},
+			remove:   []string{"typo"},
+			want:     `{"annotations":{"keep":"updated","new":"value"},"schemaVersion":2}`,
+		},
+		{
+			name:     "remove all",
+			manifest: manifest,
+			remove:   []string{"keep", "typo"},
+			want:     `{"schemaVersion":2}`,
+		},
+		{
+			name:     "set without annotations",
+			manifest: `{"schemaVersion":2,"annotations":null}`,
+			set:      map[string]string{"key": "value"},
+			want:     `{"annotations":{"key":"value"},"schemaVersion":2}`,
+		},
+		{
+			name:     "unchanged",
+			manifest: `{ "schemaVersion": 2, "annotations": {"keep": "value"} }`,
+			set:      map[string]string{"keep": "value"},
+			want:     `{ "schemaVersion": 2, "annotations": {"keep": "value"} }`,
+		},
+		{
+			name:     "remove missing annotation",
+			manifest: manifest,
+			remove:   []string{"missing"},
+			wantErr:  ErrAnnotationNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Annotate([]byte(tt.manifest), tt.set, tt.remove)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Annotate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Annotate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if _, err := Annotate([]byte(`{`), nil, nil); err == nil {
+		t.Error("Annotate() should fail for an invalid manifest")
+	}
+}
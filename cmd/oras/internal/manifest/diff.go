@@ -0,0 +1,281 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/descriptor"
+)
+
+// ChangeType is the type of a change between two manifests.
+type ChangeType string
+
+// change types
+const (
+	Added   ChangeType = "added"
+	Removed ChangeType = "removed"
+	Changed ChangeType = "changed"
+)
+
+// Diff is the structural difference between two manifests or indexes.
+type Diff struct {
+	// From is the descriptor of the manifest diffed from.
+	From ocispec.Descriptor `json:"from"`
+	// To is the descriptor of the manifest diffed to.
+	To ocispec.Descriptor `json:"to"`
+	// MediaType is the change of the media type, if any.
+	MediaType *ValueChange `json:"mediaType,omitempty"`
+	// ArtifactType is the change of the artifact type, if any.
+	ArtifactType *ValueChange `json:"artifactType,omitempty"`
+	// Config is the change of the config, if any.
+	Config *DescriptorChange `json:"config,omitempty"`
+	// Subject is the change of the subject, if any.
+	Subject *DescriptorChange `json:"subject,omitempty"`
+	// Layers are the layers added, removed or changed, matched by title.
+	Layers []DescriptorChange `json:"layers"`
+	// Manifests are the index entries added, removed or changed, matched by
+	// platform.
+	Manifests []DescriptorChange `json:"manifests"`
+	// Annotations are the annotations added, removed or changed.
+	Annotations []AnnotationChange `json:"annotations"`
+	// FromSize is the total size of the manifest diffed from and its direct
+	// references.
+	FromSize int64 `json:"fromSize"`
+	// ToSize is the total size of the manifest diffed to and its direct
+	// references.
+	ToSize int64 `json:"toSize"`
+}
+
+// ValueChange is a change of a value.
+type ValueChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DescriptorChange is a change of a referenced descriptor.
+type DescriptorChange struct {
+	Type ChangeType `json:"type"`
+	// Name identifies the descriptor, which is the title of a layer or the
+	// platform of an index entry.
+	Name string              `json:"name,omitempty"`
+	From *ocispec.Descriptor `json:"from,omitempty"`
+	To   *ocispec.Descriptor `json:"to,omitempty"`
+}
+
+// AnnotationChange is a change of an annotation.
+type AnnotationChange struct {
+	Type ChangeType `json:"type"`
+	Key  string     `json:"key"`
+	From string     `json:"from,omitempty"`
+	To   string     `json:"to,omitempty"`
+}
+
+// SizeDelta returns the difference of the total sizes.
+func (d *Diff) SizeDelta() int64 {
+	return d.ToSize - d.FromSize
+}
+
+// Empty returns true if no difference is found.
+func (d *Diff) Empty() bool {
+	return d.From.Digest == d.To.Digest ||
+		d.MediaType == nil && d.ArtifactType == nil && d.Config == nil && d.Subject == nil &&
+			len(d.Layers) == 0 && len(d.Manifests) == 0 && len(d.Annotations) == 0
+}
+
+// manifestContent contains the fields of an image manifest, an index or their
+// docker counterparts to be diffed.
+type manifestContent struct {
+	MediaType    string               `json:"mediaType"`
+	ArtifactType string               `json:"artifactType"`
+	Config       *ocispec.Descriptor  `json:"config"`
+	Layers       []ocispec.Descriptor `json:"layers"`
+	Manifests    []ocispec.Descriptor `json:"manifests"`
+	Subject      *ocispec.Descriptor  `json:"subject"`
+	Annotations  map[string]string    `json:"annotations"`
+}
+
+// size returns the total size of the manifest and its direct references.
+func (m *manifestContent) size(desc ocispec.Descriptor) int64 {
+	size := desc.Size
+	if m.Config != nil {
+		size += m.Config.Size
+	}
+	for _, layer := range m.Layers {
+		size += layer.Size
+	}
+	for _, manifest := range m.Manifests {
+		size += manifest.Size
+	}
+	return size
+}
+
+// DiffManifests returns the structural difference from the manifest content
+// described by from to the one described by to.
+func DiffManifests(from ocispec.Descriptor, fromContent []byte, to ocispec.Descriptor, toContent []byte) (*Diff, error) {
+	var a, b manifestContent
+	if err := json.Unmarshal(fromContent, &a); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", from.Digest, ErrInvalidJSON)
+	}
+	if err := json.Unmarshal(toContent, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", to.Digest, ErrInvalidJSON)
+	}
+	// the media type may only be known from the descriptor
+	if a.MediaType == "" {
+		a.MediaType = from.MediaType
+	}
+	if b.MediaType == "" {
+		b.MediaType = to.MediaType
+	}
+
+	diff := &Diff{
+		From:      descriptor.Plain(from),
+		To:        descriptor.Plain(to),
+		Config:    diffDescriptor(a.Config, b.Config),
+		Subject:   diffDescriptor(a.Subject, b.Subject),
+		Layers:    diffDescriptors(a.Layers, b.Layers, layerName),
+		Manifests: diffDescriptors(a.Manifests, b.Manifests, manifestName),
+		FromSize:  a.size(from),
+		ToSize:    b.size(to),
+	}
+	if a.MediaType != b.MediaType {
+		diff.MediaType = &ValueChange{From: a.MediaType, To: b.MediaType}
+	}
+	if a.ArtifactType != b.ArtifactType {
+		diff.ArtifactType = &ValueChange{From: a.ArtifactType, To: b.ArtifactType}
+	}
+	diff.Annotations = diffAnnotations(a.Annotations, b.Annotations)
+	return diff, nil
+}
+
+// diffDescriptor returns the change of a single descriptor, or nil if it is
+// not changed.
+func diffDescriptor(from, to *ocispec.Descriptor) *DescriptorChange {
+	switch {
+	case from == nil && to == nil:
+		return nil
+	case from == nil:
+		return &DescriptorChange{Type: Added, To: to}
+	case to == nil:
+		return &DescriptorChange{Type: Removed, From: from}
+	case descriptorEqual(*from, *to):
+		return nil
+	}
+	return &DescriptorChange{Type: Changed, From: from, To: to}
+}
+
+// diffDescriptors matches the descriptors by name and returns the removed and
+// changed descriptors in the order of from, followed by the added descriptors
+// in the order of to.
+func diffDescriptors(from, to []ocispec.Descriptor, name func(ocispec.Descriptor, int) string) []DescriptorChange {
+	changes := []DescriptorChange{}
+	fromNames := uniqueNames(from, name)
+	toNames := uniqueNames(to, name)
+	toByName := make(map[string]*ocispec.Descriptor, len(to))
+	for i, n := range toNames {
+		toByName[n] = &to[i]
+	}
+	inFrom := make(map[string]bool, len(from))
+	for i, n := range fromNames {
+		inFrom[n] = true
+		if change := diffDescriptor(&from[i], toByName[n]); change != nil {
+			change.Name = n
+			changes = append(changes, *change)
+		}
+	}
+	for i, n := range toNames {
+		if !inFrom[n] {
+			changes = append(changes, DescriptorChange{Type: Added, Name: n, To: &to[i]})
+		}
+	}
+	return changes
+}
+
+// uniqueNames returns the names of the descriptors, where a repeated name is
+// suffixed with its occurrence, e.g. "unknown/unknown (2)".
+func uniqueNames(descs []ocispec.Descriptor, name func(ocispec.Descriptor, int) string) []string {
+	names := make([]string, len(descs))
+	seen := make(map[string]int)
+	for i, desc := range descs {
+		n := name(desc, i)
+		seen[n]++
+		if count := seen[n]; count > 1 {
+			n = fmt.Sprintf("%s (%d)", n, count)
+		}
+		names[i] = n
+	}
+	return names
+}
+
+// descriptorEqual returns true if the descriptors describe the same content
+// with the same media type. Annotations are not compared.
+func descriptorEqual(a, b ocispec.Descriptor) bool {
+	return a.Digest == b.Digest && a.Size == b.Size && a.MediaType == b.MediaType
+}
+
+// layerName returns the title of a layer, or its position if untitled.
+func layerName(layer ocispec.Descriptor, i int) string {
+	if title := layer.Annotations[ocispec.AnnotationTitle]; title != "" {
+		return title
+	}
+	return fmt.Sprintf("#%d", i+1)
+}
+
+// manifestName returns the platform of an index entry, or its digest if the
+// platform is not specified.
+func manifestName(manifest ocispec.Descriptor, _ int) string {
+	p := manifest.Platform
+	if p == nil {
+		return manifest.Digest.String()
+	}
+	name := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		name += "/" + p.Variant
+	}
+	if p.OSVersion != "" {
+		name += ":" + p.OSVersion
+	}
+	return name
+}
+
+// diffAnnotations returns the changes of the annotations sorted by key.
+func diffAnnotations(from, to map[string]string) []AnnotationChange {
+	changes := []AnnotationChange{}
+	keys := slices.Sorted(maps.Keys(from))
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		a, inFrom := from[key]
+		b, inTo := to[key]
+		switch {
+		case !inTo:
+			changes = append(changes, AnnotationChange{Type: Removed, Key: key, From: a})
+		case !inFrom:
+			changes = append(changes, AnnotationChange{Type: Added, Key: key, To: b})
+		case a != b:
+			changes = append(changes, AnnotationChange{Type: Changed, Key: key, From: a, To: b})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,238 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func blobDescriptor(content string, title string) ocispec.Descriptor {
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    digest.FromString(content),
+		Size:      int64(len(content)),
+	}
+	if title != "" {
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: title}
+	}
+	return desc
+}
+
+func marshalManifest(t *testing.T, v any) (ocispec.Descriptor, []byte) {
+	t.Helper()
+	content, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, err := ExtractMediaType(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}, content
+}
+
+func TestDiffManifests_manifest(t *testing.T) {
+	config := blobDescriptor("{}", "")
+	config.MediaType = "application/vnd.test.config"
+	newConfig := blobDescriptor(`{"a":1}`, "")
+	newConfig.MediaType = "application/vnd.test.config"
+	kept := blobDescriptor("kept", "kept.txt")
+	changed := blobDescriptor("changed", "app.bin")
+	newChanged := blobDescriptor("changed again", "app.bin")
+	removed := blobDescriptor("removed", "old.txt")
+	added := blobDescriptor("added", "new.txt")
+
+	from, fromContent := marshalManifest(t, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{kept, changed, removed},
+		Annotations: map[string]string{
+			"kept":    "value",
+			"changed": "v1",
+			"removed": "value",
+		},
+	})
+	to, toContent := marshalManifest(t, ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.test",
+		Config:       newConfig,
+		Layers:       []ocispec.Descriptor{added, changed, kept},
+		Annotations: map[string]string{
+			"kept":    "value",
+			"changed": "v2",
+			"added":   "value",
+		},
+	})
+	// the layer is changed in place
+	to2, toContent2 := marshalManifest(t, ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.test",
+		Config:       newConfig,
+		Layers:       []ocispec.Descriptor{added, newChanged, kept},
+		Annotations: map[string]string{
+			"kept":    "value",
+			"changed": "v2",
+			"added":   "value",
+		},
+	})
+
+	got, err := DiffManifests(from, fromContent, to2, toContent2)
+	if err != nil {
+		t.Fatalf("DiffManifests() error = %v", err)
+	}
+	if got.Empty() {
+		t.Fatal("DiffManifests() should find differences")
+	}
+	if got.MediaType != nil {
+		t.Errorf("MediaType = %v, want nil", got.MediaType)
+	}
+	if want := (&ValueChange{To: "application/vnd.test"}); !reflect.DeepEqual(got.ArtifactType, want) {
+		t.Errorf("ArtifactType = %v, want %v", got.ArtifactType, want)
+	}
+	if want := (&DescriptorChange{Type: Changed, From: &config, To: &newConfig}); !reflect.DeepEqual(got.Config, want) {
+		t.Errorf("Config = %v, want %v", got.Config, want)
+	}
+	wantLayers := []DescriptorChange{
+		{Type: Changed, Name: "app.bin", From: &changed, To: &newChanged},
+		{Type: Removed, Name: "old.txt", From: &removed},
+		{Type: Added, Name: "new.txt", To: &added},
+	}
+	if !reflect.DeepEqual(got.Layers, wantLayers) {
+		t.Errorf("Layers = %v, want %v", got.Layers, wantLayers)
+	}
+	wantAnnotations := []AnnotationChange{
+		{Type: Added, Key: "added", To: "value"},
+		{Type: Changed, Key: "changed", From: "v1", To: "v2"},
+		{Type: Removed, Key: "removed", From: "value"},
+	}
+	if !reflect.DeepEqual(got.Annotations, wantAnnotations) {
+		t.Errorf("Annotations = %v, want %v", got.Annotations, wantAnnotations)
+	}
+	wantDelta := to2.Size - from.Size + newConfig.Size - config.Size + added.Size - removed.Size + newChanged.Size - changed.Size
+	if got.SizeDelta() != wantDelta {
+		t.Errorf("SizeDelta() = %d, want %d", got.SizeDelta(), wantDelta)
+	}
+
+	// reordered layers are not changes
+	got, err = DiffManifests(from, fromContent, to, toContent)
+	if err != nil {
+		t.Fatalf("DiffManifests() error = %v", err)
+	}
+	if len(got.Layers) != 2 {
+		t.Errorf("Layers = %v, want only the removed and the added layers", got.Layers)
+	}
+
+	// identical manifests
+	got, err = DiffManifests(from, fromContent, from, fromContent)
+	if err != nil {
+		t.Fatalf("DiffManifests() error = %v", err)
+	}
+	if !got.Empty() {
+		t.Errorf("DiffManifests() = %v, want no differences", got)
+	}
+}
+
+func TestDiffManifests_index(t *testing.T) {
+	manifest := func(content, os, arch string) ocispec.Descriptor {
+		desc := blobDescriptor(content, "")
+		desc.MediaType = ocispec.MediaTypeImageManifest
+		desc.Platform = &ocispec.Platform{OS: os, Architecture: arch}
+		return desc
+	}
+	amd64 := manifest("amd64", "linux", "amd64")
+	newAMD64 := manifest("amd64 v2", "linux", "amd64")
+	arm64 := manifest("arm64", "linux", "arm64")
+	windows := manifest("windows", "windows", "amd64")
+	from, fromContent := marshalManifest(t, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{amd64, arm64},
+	})
+	to, toContent := marshalManifest(t, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{newAMD64, windows},
+	})
+
+	got, err := DiffManifests(from, fromContent, to, toContent)
+	if err != nil {
+		t.Fatalf("DiffManifests() error = %v", err)
+	}
+	want := []DescriptorChange{
+		{Type: Changed, Name: "linux/amd64", From: &amd64, To: &newAMD64},
+		{Type: Removed, Name: "linux/arm64", From: &arm64},
+		{Type: Added, Name: "windows/amd64", To: &windows},
+	}
+	if !reflect.DeepEqual(got.Manifests, want) {
+		t.Errorf("Manifests = %v, want %v", got.Manifests, want)
+	}
+	if len(got.Layers) != 0 || got.Config != nil {
+		t.Errorf("DiffManifests() = %v, want only changes of manifests", got)
+	}
+}
+
+func TestDiffManifests_invalidContent(t *testing.T) {
+	desc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest}
+	if _, err := DiffManifests(desc, []byte("manifest"), desc, []byte("{}")); !errors.Is(err, ErrInvalidJSON) {
+		t.Errorf("DiffManifests() error = %v, want %v", err, ErrInvalidJSON)
+	}
+}
+
+func Test_uniqueNames(t *testing.T) {
+	unknown := ocispec.Descriptor{Platform: &ocispec.Platform{OS: "unknown", Architecture: "unknown"}}
+	got := uniqueNames([]ocispec.Descriptor{unknown, unknown, {Digest: digest.FromString("a")}}, manifestName)
+	want := []string{"unknown/unknown", "unknown/unknown (2)", digest.FromString("a").String()}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("uniqueNames() = %v, want %v", got, want)
+	}
+}
+
+func Test_diffDescriptors(t *testing.T) {
+	kept := blobDescriptor("kept", "kept.txt")
+	removed := blobDescriptor("removed", "removed.txt")
+	oldChanged := blobDescriptor("old", "changed.txt")
+	newChanged := blobDescriptor("new", "changed.txt")
+	added := blobDescriptor("added", "added.txt")
+	// changes to the annotations other than the title are not shown
+	annotated := blobDescriptor("kept", "kept.txt")
+	annotated.Annotations["org.example.note"] = "annotated"
+
+	from := []ocispec.Descriptor{oldChanged, kept, removed}
+	to := []ocispec.Descriptor{added, annotated, newChanged}
+	got := diffDescriptors(from, to, layerName)
+	want := []DescriptorChange{
+		{Type: Changed, Name: "changed.txt", From: &from[0], To: &to[2]},
+		{Type: Removed, Name: "removed.txt", From: &from[2]},
+		{Type: Added, Name: "added.txt", To: &to[0]},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffDescriptors() = %+v, want %+v", got, want)
+	}
+}
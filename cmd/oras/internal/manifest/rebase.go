@@ -0,0 +1,130 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+// RebaseHandler handles events of rebasing referrers.
+type RebaseHandler interface {
+	OnReferrerRebased(old, rebased ocispec.Descriptor) error
+	OnReferrerDeleted(desc ocispec.Descriptor) error
+}
+
+// Rebaser rebases referrers from one subject onto another.
+type Rebaser struct {
+	Target oras.GraphTarget
+	// Deleter deletes the old referrers if not nil.
+	Deleter content.Deleter
+	Handler RebaseHandler
+}
+
+// Rebase rebases the referrers of oldSubject, including nested referrers,
+// onto newSubject. The number of rebased referrers is returned.
+func (r *Rebaser) Rebase(ctx context.Context, oldSubject, newSubject ocispec.Descriptor) (int, error) {
+	referrers, err := registry.Referrers(ctx, r.Target, oldSubject, "")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find referrers of %s: %w", oldSubject.Digest, err)
+	}
+	var count int
+	for _, referrer := range referrers {
+		rebased, err := r.rebaseReferrer(ctx, referrer, newSubject)
+		if err != nil {
+			return 0, err
+		}
+		if err := r.Handler.OnReferrerRebased(referrer, rebased); err != nil {
+			return 0, err
+		}
+		// the subject of nested referrers has changed along with the referrer
+		nested, err := r.Rebase(ctx, referrer, rebased)
+		if err != nil {
+			return 0, err
+		}
+		count += nested + 1
+
+		if r.Deleter != nil {
+			// the referrer may have been garbage collected along with its
+			// nested referrers, e.g. in an OCI image layout
+			if err := r.Deleter.Delete(ctx, referrer); err != nil && !errors.Is(err, errdef.ErrNotFound) {
+				return 0, fmt.Errorf("failed to delete referrer %s: %w", referrer.Digest, err)
+			}
+			if err := r.Handler.OnReferrerDeleted(referrer); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return count, nil
+}
+
+// rebaseReferrer pushes a copy of referrer with its subject set to subject.
+// The descriptor of the pushed copy is returned.
+func (r *Rebaser) rebaseReferrer(ctx context.Context, referrer, subject ocispec.Descriptor) (ocispec.Descriptor, error) {
+	manifest, err := content.FetchAll(ctx, r.Target, referrer)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to fetch referrer %s: %w", referrer.Digest, err)
+	}
+	rewritten, err := SetSubject(manifest, subject)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to rebase referrer %s: %w", referrer.Digest, err)
+	}
+	rebased := content.NewDescriptorFromBytes(referrer.MediaType, rewritten)
+	rebased.ArtifactType = referrer.ArtifactType
+	rebased.Annotations = referrer.Annotations
+
+	exists, err := r.Target.Exists(ctx, rebased)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if !exists {
+		if err := r.Target.Push(ctx, rebased, bytes.NewReader(rewritten)); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to push rebased referrer %s: %w", rebased.Digest, err)
+		}
+	}
+	return rebased, nil
+}
+
+// SetSubject sets the subject field of a manifest to subject. Other fields are
+// kept as is.
+func SetSubject(manifest []byte, subject ocispec.Descriptor) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(manifest, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if _, ok := fields["subject"]; !ok {
+		return nil, errors.New("the manifest has no subject")
+	}
+	encoded, err := json.Marshal(ocispec.Descriptor{
+		MediaType: subject.MediaType,
+		Digest:    subject.Digest,
+		Size:      subject.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	fields["subject"] = encoded
+	return json.Marshal(fields)
+}
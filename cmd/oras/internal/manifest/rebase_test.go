@@ -0,0 +1,122 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+)
+
+type discardRebaseHandler struct{}
+
+func (discardRebaseHandler) OnReferrerRebased(_, _ ocispec.Descriptor) error { return nil }
+
+func (discardRebaseHandler) OnReferrerDeleted(_ ocispec.Descriptor) error { return nil }
+
+func TestRebaser_Rebase(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pack := func(artifactType string, subject *ocispec.Descriptor) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{
+			Subject:             subject,
+			ManifestAnnotations: map[string]string{"name": artifactType},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	oldSubject := pack("application/vnd.test.old", nil)
+	newSubject := pack("application/vnd.test.new", nil)
+	sbom := pack("application/vnd.test.sbom", &oldSubject)
+	signature := pack("application/vnd.test.signature", &sbom)
+
+	r := &Rebaser{
+		Target:  store,
+		Deleter: store,
+		Handler: discardRebaseHandler{},
+	}
+	count, err := r.Rebase(ctx, oldSubject, newSubject)
+	if err != nil {
+		t.Fatalf("Rebase() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Rebase() count = %d, want 2", count)
+	}
+
+	subjectOf := func(desc ocispec.Descriptor) ocispec.Descriptor {
+		t.Helper()
+		manifestJSON, err := content.FetchAll(ctx, store, desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var manifest ocispec.Manifest
+		if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+			t.Fatal(err)
+		}
+		if manifest.Annotations["name"] != desc.ArtifactType {
+			t.Errorf("annotations of %s are not kept: %v", desc.Digest, manifest.Annotations)
+		}
+		return *manifest.Subject
+	}
+	referrers, err := registry.Referrers(ctx, store, newSubject, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(referrers) != 1 || referrers[0].ArtifactType != sbom.ArtifactType {
+		t.Fatalf("referrers of new subject = %v, want the rebased sbom", referrers)
+	}
+	rebasedSBOM := referrers[0]
+	if got := subjectOf(rebasedSBOM); got.Digest != newSubject.Digest {
+		t.Errorf("subject of rebased sbom = %s, want %s", got.Digest, newSubject.Digest)
+	}
+	referrers, err = registry.Referrers(ctx, store, rebasedSBOM, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(referrers) != 1 || referrers[0].ArtifactType != signature.ArtifactType {
+		t.Fatalf("referrers of rebased sbom = %v, want the rebased signature", referrers)
+	}
+	if got := subjectOf(referrers[0]); got.Digest != rebasedSBOM.Digest {
+		t.Errorf("subject of rebased signature = %s, want %s", got.Digest, rebasedSBOM.Digest)
+	}
+
+	for _, desc := range []ocispec.Descriptor{sbom, signature} {
+		if exists, err := store.Exists(ctx, desc); err != nil || exists {
+			t.Errorf("old referrer %s should be deleted", desc.Digest)
+		}
+	}
+}
+
+func TestSetSubject(t *testing.T) {
+	if _, err := SetSubject([]byte(`{"schemaVersion":2}`), ocispec.Descriptor{}); err == nil {
+		t.Error("SetSubject() should fail for a manifest without subject")
+	}
+	if _, err := SetSubject([]byte(`{`), ocispec.Descriptor{}); err == nil {
+		t.Error("SetSubject() should fail for an invalid manifest")
+	}
+}
@@ -0,0 +1,297 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/internal/docker"
+)
+
+// Severity is the severity of a validation finding.
+type Severity string
+
+// severities of validation findings
+const (
+	// SeverityError indicates the manifest violates the specification.
+	SeverityError Severity = "error"
+	// SeverityWarning indicates the manifest may not be portable or may not
+	// work as intended.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo indicates a check is skipped or informational.
+	SeverityInfo Severity = "info"
+)
+
+// maxManifestSize is the manifest size accepted by most registries.
+const maxManifestSize = 4 * 1024 * 1024
+
+// mediaTypeRegexp matches a media type in the format of RFC 6838, the same as
+// the one used by the OCI image-spec schemas.
+var mediaTypeRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}/[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
+
+// reservedAnnotationPrefix is the prefix of the annotation keys reserved by
+// the OCI image-spec.
+const reservedAnnotationPrefix = "org.opencontainers."
+
+// knownAnnotations are the pre-defined annotation keys of the OCI image-spec.
+var knownAnnotations = map[string]bool{
+	ocispec.AnnotationCreated:         true,
+	ocispec.AnnotationAuthors:         true,
+	ocispec.AnnotationURL:             true,
+	ocispec.AnnotationDocumentation:   true,
+	ocispec.AnnotationSource:          true,
+	ocispec.AnnotationVersion:         true,
+	ocispec.AnnotationRevision:        true,
+	ocispec.AnnotationVendor:          true,
+	ocispec.AnnotationLicenses:        true,
+	ocispec.AnnotationRefName:         true,
+	ocispec.AnnotationTitle:           true,
+	ocispec.AnnotationDescription:     true,
+	ocispec.AnnotationBaseImageDigest: true,
+	ocispec.AnnotationBaseImageName:   true,
+}
+
+// Finding is a problem found in a manifest.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	// Field is the path of the field with the problem, e.g. "layers[0].digest".
+	Field string `json:"field,omitempty"`
+	// Message describes the problem.
+	Message string `json:"message"`
+	// Recommendation suggests how to fix the problem.
+	Recommendation string `json:"recommendation,omitempty"`
+}
+
+// Findings are the findings of a validation.
+type Findings []Finding
+
+// Count returns the number of findings of the severity.
+func (f Findings) Count(severity Severity) int {
+	var count int
+	for _, finding := range f {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// validatedManifest contains the fields of an image manifest, an index or
+// their docker counterparts to be validated.
+type validatedManifest struct {
+	SchemaVersion *int                 `json:"schemaVersion"`
+	MediaType     string               `json:"mediaType"`
+	ArtifactType  string               `json:"artifactType"`
+	Config        *ocispec.Descriptor  `json:"config"`
+	Layers        []ocispec.Descriptor `json:"layers"`
+	Manifests     []ocispec.Descriptor `json:"manifests"`
+	Subject       *ocispec.Descriptor  `json:"subject"`
+	Annotations   map[string]string    `json:"annotations"`
+}
+
+// validator collects the findings of a manifest.
+type validator struct {
+	findings Findings
+}
+
+func (v *validator) add(severity Severity, field, message, recommendation string) {
+	v.findings = append(v.findings, Finding{
+		Severity:       severity,
+		Field:          field,
+		Message:        message,
+		Recommendation: recommendation,
+	})
+}
+
+// Validate validates the manifest content of the media type against the OCI
+// image-spec. The subject of the manifest is checked in storage if storage is
+// not nil.
+func Validate(ctx context.Context, mediaType string, manifestBytes []byte, storage content.ReadOnlyStorage) (Findings, error) {
+	v := &validator{
+		findings: Findings{},
+	}
+	var m validatedManifest
+	if err := json.Unmarshal(manifestBytes, &m); err != nil {
+		v.add(SeverityError, "", fmt.Sprintf("not a valid JSON object: %v", err), "Check the syntax of the manifest.")
+		return v.findings, nil
+	}
+	if len(manifestBytes) > maxManifestSize {
+		v.add(SeverityWarning, "", fmt.Sprintf("the manifest size %d exceeds %d bytes", len(manifestBytes), maxManifestSize), "Most registries reject manifests larger than 4 MiB, consider splitting the content.")
+	}
+	if m.SchemaVersion == nil || *m.SchemaVersion != 2 {
+		v.add(SeverityError, "schemaVersion", "schemaVersion must be 2", `Set "schemaVersion" to 2.`)
+	}
+
+	switch {
+	case m.MediaType == "" && mediaType == "":
+		v.add(SeverityError, "mediaType", "media type is not specified", `Set "mediaType" in the manifest.`)
+		return v.findings, nil
+	case m.MediaType == "":
+		v.add(SeverityWarning, "mediaType", "mediaType is not set in the manifest", fmt.Sprintf(`Set "mediaType" to %q so that the manifest can be identified without the descriptor.`, mediaType))
+	case mediaType == "":
+		mediaType = m.MediaType
+	case m.MediaType != mediaType:
+		v.add(SeverityError, "mediaType", fmt.Sprintf("mediaType %q does not match the media type %q of the descriptor", m.MediaType, mediaType), "Use the same media type in the manifest and when pushing it.")
+	}
+
+	switch mediaType {
+	case ocispec.MediaTypeImageManifest, docker.MediaTypeManifest:
+		v.validateImageManifest(&m)
+	case ocispec.MediaTypeImageIndex, docker.MediaTypeManifestList:
+		v.validateIndex(&m)
+	default:
+		v.add(SeverityError, "mediaType", fmt.Sprintf("unsupported manifest media type %q", mediaType), fmt.Sprintf("Use %q or %q.", ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex))
+		return v.findings, nil
+	}
+
+	if m.ArtifactType != "" && !mediaTypeRegexp.MatchString(m.ArtifactType) {
+		v.add(SeverityError, "artifactType", fmt.Sprintf("invalid artifactType %q", m.ArtifactType), "Use a media type in the form of type/subtype, e.g. application/vnd.example+json.")
+	}
+	if m.Subject != nil {
+		v.validateDescriptor("subject", *m.Subject)
+		if err := v.validateSubject(ctx, *m.Subject, storage); err != nil {
+			return nil, err
+		}
+	}
+	v.validateAnnotations("annotations", m.Annotations)
+	return v.findings, nil
+}
+
+// validateImageManifest validates the fields of an image manifest.
+func (v *validator) validateImageManifest(m *validatedManifest) {
+	if m.Config == nil {
+		v.add(SeverityError, "config", "config is required", "Set the config, or use the empty descriptor if there is no config.")
+	} else {
+		v.validateDescriptor("config", *m.Config)
+		switch {
+		case m.Config.MediaType == ocispec.MediaTypeEmptyJSON && m.ArtifactType == "":
+			v.add(SeverityError, "artifactType", "artifactType must be set when the config is the empty descriptor", `Set "artifactType" to the type of the artifact.`)
+		case m.ArtifactType != "" && m.Config.MediaType == ocispec.MediaTypeImageConfig:
+			v.add(SeverityWarning, "artifactType", fmt.Sprintf("artifactType %q is set on an image with config media type %q", m.ArtifactType, m.Config.MediaType), `Remove "artifactType" from the image, or use the empty descriptor as the config of the artifact.`)
+		case m.ArtifactType != "" && m.Config.MediaType != ocispec.MediaTypeEmptyJSON && m.ArtifactType != m.Config.MediaType:
+			v.add(SeverityWarning, "artifactType", fmt.Sprintf("artifactType %q is inconsistent with the config media type %q", m.ArtifactType, m.Config.MediaType), "Use the same type for both, or use the empty descriptor as the config.")
+		}
+	}
+	if m.Layers == nil {
+		v.add(SeverityError, "layers", "layers is required", `Set "layers" to an array, which may be empty for an artifact.`)
+	} else if len(m.Layers) == 0 && m.ArtifactType == "" {
+		v.add(SeverityWarning, "layers", "layers is empty", "For portability, an image should have at least one layer.")
+	}
+	for i, layer := range m.Layers {
+		v.validateDescriptor(fmt.Sprintf("layers[%d]", i), layer)
+	}
+	if m.Manifests != nil {
+		v.add(SeverityWarning, "manifests", "manifests is not a field of an image manifest", `Remove "manifests", or push the content as an index.`)
+	}
+}
+
+// validateIndex validates the fields of an index.
+func (v *validator) validateIndex(m *validatedManifest) {
+	if m.Manifests == nil {
+		v.add(SeverityError, "manifests", "manifests is required", `Set "manifests" to an array of manifest descriptors.`)
+	}
+	for i, desc := range m.Manifests {
+		field := fmt.Sprintf("manifests[%d]", i)
+		v.validateDescriptor(field, desc)
+		switch desc.MediaType {
+		case ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex, docker.MediaTypeManifest, docker.MediaTypeManifestList:
+		default:
+			v.add(SeverityWarning, field+".mediaType", fmt.Sprintf("%q is not a manifest media type", desc.MediaType), "An index should only reference manifests or indexes.")
+		}
+	}
+	if m.Config != nil || m.Layers != nil {
+		v.add(SeverityWarning, "config", "config and layers are not fields of an index", `Remove "config" and "layers", or push the content as an image manifest.`)
+	}
+}
+
+// validateDescriptor validates the fields of a descriptor.
+func (v *validator) validateDescriptor(field string, desc ocispec.Descriptor) {
+	if desc.MediaType == "" {
+		v.add(SeverityError, field+".mediaType", "mediaType is required", "Set the media type of the referenced content.")
+	} else if !mediaTypeRegexp.MatchString(desc.MediaType) {
+		v.add(SeverityError, field+".mediaType", fmt.Sprintf("invalid mediaType %q", desc.MediaType), "Use a media type in the form of type/subtype, e.g. application/vnd.example+json.")
+	}
+
+	validDigest := true
+	if err := desc.Digest.Validate(); err != nil {
+		validDigest = false
+		v.add(SeverityError, field+".digest", fmt.Sprintf("invalid digest %q: %v", desc.Digest, err), "Use the digest of the content in the form of <algorithm>:<encoded>, e.g. sha256:<64 hex characters>.")
+	}
+	emptyDigest := validDigest && desc.Digest == desc.Digest.Algorithm().FromBytes(nil)
+	switch {
+	case desc.Size < 0:
+		v.add(SeverityError, field+".size", fmt.Sprintf("invalid size %d", desc.Size), "Use the size of the content in bytes.")
+	case desc.Size == 0 && validDigest && !emptyDigest:
+		v.add(SeverityError, field+".size", "size is 0 but the digest is not of empty content", "Use the size of the content in bytes.")
+	case desc.Size > 0 && emptyDigest:
+		v.add(SeverityError, field+".size", fmt.Sprintf("size is %d but the digest is of empty content", desc.Size), "Use the digest and the size of the same content.")
+	}
+	if desc.Data != nil {
+		if int64(len(desc.Data)) != desc.Size {
+			v.add(SeverityError, field+".data", fmt.Sprintf("size of the embedded data %d does not match the size %d", len(desc.Data), desc.Size), "Embed the referenced content as data, or remove the data.")
+		} else if validDigest && desc.Digest.Algorithm().FromBytes(desc.Data) != desc.Digest {
+			v.add(SeverityError, field+".data", "digest of the embedded data does not match the digest", "Embed the referenced content as data, or remove the data.")
+		}
+	}
+	v.validateAnnotations(field+".annotations", desc.Annotations)
+}
+
+// validateAnnotations validates the annotation keys and the values of the
+// pre-defined annotations.
+func (v *validator) validateAnnotations(field string, annotations map[string]string) {
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		value := annotations[key]
+		keyField := fmt.Sprintf("%s[%q]", field, key)
+		if strings.HasPrefix(key, reservedAnnotationPrefix) && !knownAnnotations[key] {
+			v.add(SeverityWarning, keyField, fmt.Sprintf("annotation key %q uses the prefix %q reserved by the OCI image-spec", key, reservedAnnotationPrefix), "Use a key in your own reverse domain notation, e.g. com.example.key.")
+		}
+		if key == ocispec.AnnotationCreated {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				v.add(SeverityWarning, keyField, fmt.Sprintf("invalid creation time %q", value), "Use a date and time in RFC 3339 format, e.g. 2006-01-02T15:04:05Z.")
+			}
+		}
+	}
+}
+
+// validateSubject checks that the subject can be resolved from storage. A
+// missing subject is only a warning, as a referrer may be pushed before its
+// subject.
+func (v *validator) validateSubject(ctx context.Context, subject ocispec.Descriptor, storage content.ReadOnlyStorage) error {
+	if storage == nil {
+		v.add(SeverityInfo, "subject", "subject is not checked as no target is given", "")
+		return nil
+	}
+	if subject.Digest.Validate() != nil {
+		return nil
+	}
+	exists, err := storage.Exists(ctx, subject)
+	if err != nil {
+		return fmt.Errorf("failed to check subject %s: %w", subject.Digest, err)
+	}
+	if !exists {
+		v.add(SeverityWarning, "subject", fmt.Sprintf("subject %s is not found", subject.Digest), "Check the digest of the subject if it is expected to exist already.")
+	}
+	return nil
+}
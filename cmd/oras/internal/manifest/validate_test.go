@@ -0,0 +1,156 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+	storage := memory.New()
+	subjectContent := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[]}`)
+	subject := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, subjectContent)
+	if err := storage.Push(ctx, subject, bytes.NewReader(subjectContent)); err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		emptyConfig = `"config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2}`
+		layer       = `{"mediaType":"application/vnd.oci.image.layer.v1.tar","digest":"sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03","size":6}`
+		manifestPre = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json",`
+	)
+	tests := []struct {
+		name      string
+		mediaType string
+		content   string
+		noStorage bool
+		// want are the fields of the findings with their severities
+		want []string
+	}{
+		{
+			name:    "valid artifact",
+			content: manifestPre + `"artifactType":"application/vnd.test",` + emptyConfig + `,"layers":[` + layer + `]}`,
+		},
+		{
+			name:    "valid index",
+			content: `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"` + subject.Digest.String() + `","size":1}]}`,
+		},
+		{
+			name:    "invalid JSON",
+			content: "manifest",
+			want:    []string{"error:"},
+		},
+		{
+			name:    "missing media type",
+			content: `{"schemaVersion":2}`,
+			want:    []string{"error:mediaType"},
+		},
+		{
+			name:      "media type from the descriptor",
+			mediaType: ocispec.MediaTypeImageManifest,
+			content:   `{"schemaVersion":2,"artifactType":"application/vnd.test",` + emptyConfig + `,"layers":[]}`,
+			want:      []string{"warning:mediaType"},
+		},
+		{
+			name:      "mismatched media type",
+			mediaType: ocispec.MediaTypeImageIndex,
+			content:   manifestPre + `"artifactType":"application/vnd.test",` + emptyConfig + `,"layers":[]}`,
+			want:      []string{"error:mediaType", "error:manifests", "warning:config"},
+		},
+		{
+			name:    "unsupported media type",
+			content: `{"schemaVersion":2,"mediaType":"application/json"}`,
+			want:    []string{"error:mediaType"},
+		},
+		{
+			name:    "missing schema version, config and layers",
+			content: `{"mediaType":"application/vnd.oci.image.manifest.v1+json"}`,
+			want:    []string{"error:schemaVersion", "error:config", "error:layers"},
+		},
+		{
+			name:    "missing artifact type",
+			content: manifestPre + emptyConfig + `,"layers":[` + layer + `]}`,
+			want:    []string{"error:artifactType"},
+		},
+		{
+			name:    "artifact type on an image",
+			content: manifestPre + `"artifactType":"application/vnd.test","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[` + layer + `]}`,
+			want:    []string{"warning:artifactType"},
+		},
+		{
+			name:    "invalid descriptors",
+			content: manifestPre + `"artifactType":"application/vnd.test",` + emptyConfig + `,"layers":[{"mediaType":"text","digest":"sha256:abc","size":1},{"mediaType":"text/plain","digest":"sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03","size":0},{"mediaType":"text/plain","digest":"sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03","size":6,"data":"aGVsbG8K"}]}`,
+			want:    []string{"error:layers[0].mediaType", "error:layers[0].digest", "error:layers[1].size"},
+		},
+		{
+			name:    "mismatched data",
+			content: manifestPre + `"artifactType":"application/vnd.test",` + emptyConfig + `,"layers":[{"mediaType":"text/plain","digest":"sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03","size":6,"data":"d29ybGQK"}]}`,
+			want:    []string{"error:layers[0].data"},
+		},
+		{
+			name:    "reserved annotations",
+			content: manifestPre + `"artifactType":"application/vnd.test",` + emptyConfig + `,"layers":[],"annotations":{"org.opencontainers.image.created":"yesterday","org.opencontainers.custom":"value","com.example.key":"value"}}`,
+			want:    []string{`warning:annotations["org.opencontainers.custom"]`, `warning:annotations["org.opencontainers.image.created"]`},
+		},
+		{
+			name:    "resolvable subject",
+			content: manifestPre + `"artifactType":"application/vnd.test",` + emptyConfig + `,"layers":[],"subject":{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"` + subject.Digest.String() + `","size":` + strconv.Itoa(len(subjectContent)) + `}}`,
+		},
+		{
+			name:    "missing subject",
+			content: manifestPre + `"artifactType":"application/vnd.test",` + emptyConfig + `,"layers":[],"subject":{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03","size":6}}`,
+			want:    []string{"warning:subject"},
+		},
+		{
+			name:      "subject not checked",
+			content:   manifestPre + `"artifactType":"application/vnd.test",` + emptyConfig + `,"layers":[],"subject":{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03","size":6}}`,
+			noStorage: true,
+			want:      []string{"info:subject"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s content.ReadOnlyStorage = storage
+			if tt.noStorage {
+				s = nil
+			}
+			findings, err := Validate(ctx, tt.mediaType, []byte(tt.content), s)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			got := []string{}
+			for _, f := range findings {
+				got = append(got, string(f.Severity)+":"+f.Field)
+			}
+			want := tt.want
+			if want == nil {
+				want = []string{}
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("Validate() = %v, want %v", findings, want)
+			}
+		})
+	}
+}
@@ -30,9 +30,12 @@ import (
 // image layouts.
 // BinaryTarget implements errors.Handler interface.
 type BinaryTarget struct {
-	From        Target
-	To          Target
-	resolveFlag []string
+	From Target
+	To   Target
+	// DestinationOnly indicates only the destination is specified, in which
+	// case the source is not parsed.
+	DestinationOnly bool
+	resolveFlag     []string
 }
 
 // EnsureSourceTargetReferenceNotEmpty ensures that from target reference is not empty.
@@ -65,6 +68,9 @@ func (target *BinaryTarget) Parse(cmd *cobra.Command) error {
 	// resolve are parsed in array order, latter will overwrite former
 	target.From.resolveFlag = append(target.resolveFlag, target.From.resolveFlag...)
 	target.To.resolveFlag = append(target.resolveFlag, target.To.resolveFlag...)
+	if target.DestinationOnly {
+		return target.To.Parse(cmd)
+	}
 	return Parse(cmd, target)
 }
 
@@ -69,6 +69,14 @@ var (
 		Name:  "text",
 		Usage: "Print in text format",
 	}
+	FormatTypeDOT = &FormatType{
+		Name:  "dot",
+		Usage: "Print in Graphviz DOT format",
+	}
+	FormatTypeMermaid = &FormatType{
+		Name:  "mermaid",
+		Usage: "Print in Mermaid flowchart format",
+	}
 )
 
 // Format contains input and parsed options for formatted output flags.
@@ -45,12 +45,22 @@ func (opts *Platform) Parse(*cobra.Command) error {
 	if opts.platform == "" {
 		return nil
 	}
+	p, err := ParsePlatform(opts.platform)
+	if err != nil {
+		return err
+	}
+	opts.Platform = p
+	return nil
+}
 
+// ParsePlatform parses a platform string in the form of
+// `os[/arch][/variant][:os_version]` to an oci platform type.
+func ParsePlatform(platform string) (*ocispec.Platform, error) {
 	// OS[/Arch[/Variant]][:OSVersion]
 	// If Arch is not provided, will use GOARCH instead
 	var platformStr string
 	var p ocispec.Platform
-	platformStr, p.OSVersion, _ = strings.Cut(opts.platform, ":")
+	platformStr, p.OSVersion, _ = strings.Cut(platform, ":")
 	parts := strings.Split(platformStr, "/")
 	switch len(parts) {
 	case 3:
@@ -61,17 +71,16 @@ func (opts *Platform) Parse(*cobra.Command) error {
 	case 1:
 		p.Architecture = runtime.GOARCH
 	default:
-		return fmt.Errorf("failed to parse platform %q: expected format os[/arch[/variant]]", opts.platform)
+		return nil, fmt.Errorf("failed to parse platform %q: expected format os[/arch[/variant]]", platform)
 	}
 	p.OS = parts[0]
 	if p.OS == "" {
-		return fmt.Errorf("invalid platform: OS cannot be empty")
+		return nil, fmt.Errorf("invalid platform: OS cannot be empty")
 	}
 	if p.Architecture == "" {
-		return fmt.Errorf("invalid platform: Architecture cannot be empty")
+		return nil, fmt.Errorf("invalid platform: Architecture cannot be empty")
 	}
-	opts.Platform = &p
-	return nil
+	return &p, nil
 }
 
 // ArtifactPlatform option struct.
@@ -84,3 +93,31 @@ func (opts *ArtifactPlatform) ApplyFlags(fs *pflag.FlagSet) {
 	opts.FlagDescription = "set artifact platform"
 	fs.StringVarP(&opts.platform, "artifact-platform", "", "", "[Experimental] "+opts.FlagDescription+" in the form of `os[/arch][/variant][:os_version]`")
 }
+
+// Platforms option struct for commands accepting multiple platforms.
+type Platforms struct {
+	platforms       []string
+	Platforms       []*ocispec.Platform
+	FlagDescription string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Platforms) ApplyFlags(fs *pflag.FlagSet) {
+	if opts.FlagDescription == "" {
+		opts.FlagDescription = "request platforms"
+	}
+	fs.StringSliceVarP(&opts.platforms, "platform", "", nil, opts.FlagDescription+" in the form of `os[/arch][/variant][:os_version]`, multiple platforms are separated by commas")
+}
+
+// Parse parses the input platform flag to oci platform types.
+func (opts *Platforms) Parse(*cobra.Command) error {
+	opts.Platforms = nil
+	for _, platform := range opts.platforms {
+		p, err := ParsePlatform(platform)
+		if err != nil {
+			return err
+		}
+		opts.Platforms = append(opts.Platforms, p)
+	}
+	return nil
+}
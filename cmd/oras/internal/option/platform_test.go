@@ -80,3 +80,22 @@ func TestPlatform_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestPlatforms_Parse(t *testing.T) {
+	opts := &Platforms{platforms: []string{"linux/amd64", "linux/arm64/v8"}}
+	if err := opts.Parse(nil); err != nil {
+		t.Fatalf("Platforms.Parse() error = %v", err)
+	}
+	want := []*ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64", Variant: "v8"},
+	}
+	if !reflect.DeepEqual(opts.Platforms, want) {
+		t.Errorf("Platforms.Parse() = %v, want %v", opts.Platforms, want)
+	}
+
+	opts = &Platforms{platforms: []string{"linux/amd64", "/arm64"}}
+	if err := opts.Parse(nil); err == nil {
+		t.Error("Platforms.Parse() error = nil, want error")
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"oras.land/oras-go/v2/registry/remote/errcode"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/internal/contentutil"
 )
 
 const (
@@ -107,25 +108,51 @@ func (target *Target) Parse(cmd *cobra.Command) error {
 		if len(target.headerFlags) != 0 {
 			return errors.New("custom header flags cannot be used on an OCI image layout target")
 		}
-		return target.parseOCILayoutReference()
+		return target.parseReference()
 	case target.Path != "":
 		target.Type = TargetTypeOCILayout
+		return target.parseReference()
+	default:
+		target.Type = TargetTypeRemote
+		if err := target.parseReference(); err != nil {
+			return err
+		}
+		return target.Remote.Parse(cmd)
+	}
+}
+
+// WithReference returns a copy of the parsed target pointing to rawReference.
+// Flags of the target are kept and credentials are not read again.
+func (target *Target) WithReference(rawReference string) (*Target, error) {
+	clone := *target
+	clone.RawReference = rawReference
+	if err := clone.parseReference(); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
+// parseReference parses the raw reference based on the target type.
+func (target *Target) parseReference() error {
+	switch {
+	case target.Type == TargetTypeOCILayout && target.IsOCILayout:
+		return target.parseOCILayoutReference()
+	case target.Type == TargetTypeOCILayout:
 		target.Reference = target.RawReference
 		return nil
 	default:
-		target.Type = TargetTypeRemote
-		if ref, err := registry.ParseReference(target.RawReference); err != nil {
+		ref, err := registry.ParseReference(target.RawReference)
+		if err != nil {
 			return &oerrors.Error{
 				OperationType:  oerrors.OperationTypeParseArtifactReference,
 				Err:            fmt.Errorf("%q: %w", target.RawReference, err),
 				Recommendation: "Please make sure the provided reference is in the form of <registry>/<repo>[:tag|@digest]",
 			}
-		} else {
-			target.Reference = ref.Reference
-			ref.Reference = ""
-			target.Path = ref.String()
 		}
-		return target.Remote.Parse(cmd)
+		target.Reference = ref.Reference
+		ref.Reference = ""
+		target.Path = ref.String()
+		return nil
 	}
 }
 
@@ -200,32 +227,43 @@ func (target *Target) NewManifestDeleter(common Common, logger logrus.FieldLogge
 	return nil, fmt.Errorf("unknown target type: %q", target.Type)
 }
 
-// NewReadonlyTarget generates a new read only target based on target.
-func (target *Target) NewReadonlyTarget(ctx context.Context, common Common, logger logrus.FieldLogger) (ReadOnlyGraphTagFinderTarget, error) {
+// NewReadonlyTarget generates a new read only target based on target. The
+// returned closer releases the files backing the target, such as the
+// decompressed copy of a compressed tar archive, and must be closed once the
+// target is no longer used.
+func (target *Target) NewReadonlyTarget(ctx context.Context, common Common, logger logrus.FieldLogger) (ReadOnlyGraphTagFinderTarget, io.Closer, error) {
 	switch target.Type {
 	case TargetTypeOCILayout:
 		info, err := os.Stat(target.Path)
 		if err != nil {
 			if errors.Is(err, fs.ErrNotExist) {
-				return nil, fmt.Errorf("invalid argument %q: failed to find path %q: %w", target.RawReference, target.Path, err)
+				return nil, nil, fmt.Errorf("invalid argument %q: failed to find path %q: %w", target.RawReference, target.Path, err)
 			}
-			return nil, err
+			return nil, nil, err
 		}
 		if info.IsDir() {
-			return oci.NewFromFS(ctx, os.DirFS(target.Path))
+			store, err := oci.NewFromFS(ctx, os.DirFS(target.Path))
+			if err != nil {
+				return nil, nil, err
+			}
+			return store, contentutil.NopCloser{}, nil
 		}
-		store, err := oci.NewFromTar(ctx, target.Path)
+		store, closer, err := contentutil.NewOCIStoreFromTar(ctx, target.Path)
 		if err != nil {
 			if errors.Is(err, io.ErrUnexpectedEOF) {
-				return nil, fmt.Errorf("%q does not look like a tar archive: %w", target.Path, err)
+				return nil, nil, fmt.Errorf("%q does not look like a tar archive: %w", target.Path, err)
 			}
-			return nil, err
+			return nil, nil, err
 		}
-		return store, nil
+		return store, closer, nil
 	case TargetTypeRemote:
-		return target.NewRepository(target.RawReference, common, logger)
+		repo, err := target.NewRepository(target.RawReference, common, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		return repo, contentutil.NopCloser{}, nil
 	}
-	return nil, fmt.Errorf("unknown target type: %q", target.Type)
+	return nil, nil, fmt.Errorf("unknown target type: %q", target.Type)
 }
 
 // EnsureReferenceNotEmpty returns formalized error when the reference is empty.
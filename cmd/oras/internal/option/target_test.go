@@ -16,17 +16,27 @@ limitations under the License.
 package option
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote/errcode"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/contentutil"
+	orasio "oras.land/oras/internal/io"
 )
 
 func TestTarget_Parse_oci_path(t *testing.T) {
@@ -121,6 +131,42 @@ func TestTarget_Parse_remote_err(t *testing.T) {
 	}
 }
 
+func TestTarget_WithReference(t *testing.T) {
+	remote := Target{RawReference: "localhost:5000/repo:v1"}
+	cmd := &cobra.Command{}
+	ApplyFlags(&remote, cmd.Flags())
+	if err := remote.Parse(cmd); err != nil {
+		t.Fatalf("Target.Parse() error = %v", err)
+	}
+	clone, err := remote.WithReference("localhost:6000/other:v2")
+	if err != nil {
+		t.Fatalf("Target.WithReference() error = %v", err)
+	}
+	if clone.Type != TargetTypeRemote || clone.Path != "localhost:6000/other" || clone.Reference != "v2" {
+		t.Errorf("Target.WithReference() = %+v", clone)
+	}
+	if remote.Path != "localhost:5000/repo" || remote.Reference != "v1" {
+		t.Errorf("Target.WithReference() should not modify the original target: %+v", remote)
+	}
+	if _, err := remote.WithReference("localhost:6000/INVALID"); err == nil {
+		t.Error("Target.WithReference() error = nil, want error for invalid reference")
+	}
+
+	layout := Target{RawReference: "./layout:v1", IsOCILayout: true}
+	cmd = &cobra.Command{}
+	ApplyFlags(&layout, cmd.Flags())
+	if err := layout.Parse(cmd); err != nil {
+		t.Fatalf("Target.Parse() error = %v", err)
+	}
+	clone, err = layout.WithReference("./other:v2")
+	if err != nil {
+		t.Fatalf("Target.WithReference() error = %v", err)
+	}
+	if clone.Type != TargetTypeOCILayout || clone.Path != "./other" || clone.Reference != "v2" {
+		t.Errorf("Target.WithReference() = %+v", clone)
+	}
+}
+
 func Test_parseOCILayoutReference(t *testing.T) {
 	opts := Target{
 		RawReference: "/test",
@@ -419,3 +465,54 @@ func TestTarget_ModifyError_dockerHint(t *testing.T) {
 		})
 	}
 }
+
+func TestTarget_NewReadonlyTarget_ociLayoutArchive(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	cw, err := orasio.NewCompressWriter(&buf, orasio.CompressionGzip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := contentutil.NewTarWriter(cw)
+	if err := oras.CopyGraph(ctx, src, tw, root, oras.DefaultCopyGraphOptions); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "layout.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Target{
+		Type:         TargetTypeOCILayout,
+		RawReference: path + ":v1",
+		Path:         path,
+	}
+	target, closer, err := opts.NewReadonlyTarget(ctx, Common{}, logrus.New())
+	if err != nil {
+		t.Fatalf("NewReadonlyTarget() error = %v", err)
+	}
+	if got, err := target.Resolve(ctx, "v1"); err != nil || got.Digest != root.Digest {
+		t.Errorf("Resolve() = %v, %v, want %v", got, err, root)
+	}
+	if err := closer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	// the decompressed archive is released
+	if _, err := content.FetchAll(ctx, target, root); err == nil {
+		t.Error("FetchAll() after Close() error = nil, want error")
+	}
+}
@@ -20,9 +20,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,11 +40,19 @@ import (
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/checkpoint"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/docker"
 	"oras.land/oras/internal/graph"
+	"oras.land/oras/internal/inventory"
 	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/repository"
+	"oras.land/oras/internal/version"
 )
 
 // outputFormat defines the format of the backup output.
@@ -54,6 +65,16 @@ const (
 	outputFormatTar
 )
 
+// annotationBackupBase is the annotation of index.json in an incremental
+// backup, listing the paths of the backups it is based on relative to the
+// backup, from the full backup to the latest one.
+const annotationBackupBase = "land.oras.backup.base"
+
+// annotationBackupReferrers is the annotation of a tagged manifest in the
+// index.json of a backup including referrers, recording the number of
+// referrers backed up with the artifact.
+const annotationBackupReferrers = "land.oras.backup.referrers"
+
 // errTagListNotSupported is returned when the target does not support tag listing.
 var errTagListNotSupported = errors.New("the target does not support tag listing")
 
@@ -66,20 +87,40 @@ type backupOptions struct {
 	output           string
 	includeReferrers bool
 	concurrency      int
+	checkpoint       string
+	incrementalFrom  []string
+	compressionName  string
+	volumeSizeFlag   string
+	tagRegex         string
+	tagSemver        string
+	excludeTags      []string
+	since            string
 
 	// derived options
 	outputFormat outputFormat
+	compression  orasio.Compression
+	volumeSize   int64
+	tagFilter    *tagFilter
 	repository   string
 	tags         []string
+	// allRepositories indicates all repositories under the namespace of the
+	// registry are backed up.
+	allRepositories bool
+	hostname        string
+	namespace       string
 }
 
 func backupCmd() *cobra.Command {
 	var opts backupOptions
 	cmd := &cobra.Command{
-		Use:   "backup [flags] --output <path> <registry>/<repository>[:<ref1>[,<ref2>...]]",
+		Use:   "backup [flags] --output <path> {<registry>/<repository>[:<ref1>[,<ref2>...]]|<registry>/[<namespace>/]}",
 		Short: "[Experimental] Back up artifacts from a registry into an OCI image layout",
 		Long: `[Experimental] Back up artifacts from a registry into an OCI image layout, saved either as a directory or a tar archive.
-The output format is determined by the file extension of the specified output path: if it ends with ".tar", the output will be a tar archive; otherwise, it will be a directory.
+The output format is determined by the file extension of the specified output path: if it ends with ".tar", the output will be a tar archive; if it ends with ".tar.gz" or ".tgz", the output will be a gzip compressed tar archive; if it ends with ".tar.zst" or ".tar.zstd", the output will be a zstd compressed tar archive; otherwise, it will be a directory. The compression of a tar archive can also be specified by --compression. If the output path is "-", a tar archive is written to stdout.
+
+Tar archives are streamed as the artifacts are pulled, without a temporary copy of the backup, unless --checkpoint is specified to resume an interrupted backup. Blobs are pulled one at a time when streamed, regardless of --concurrency.
+
+An inventory.json file listing the backed up tags with their digests, platforms and referrers is written into the backup, which can be printed by "oras restore --list".
 
 Example - Back up a single artifact to a directory:
   oras backup --output hello localhost:5000/hello:v1
@@ -87,6 +128,18 @@ Example - Back up a single artifact to a directory:
 Example - Back up to a tar archive:
   oras backup --output hello.tar localhost:5000/hello:v1
 
+Example - Back up to a gzip compressed tar archive:
+  oras backup --output hello.tar.gz localhost:5000/hello:v1
+
+Example - Back up to a zstd compressed tar archive:
+  oras backup --output hello.tar.zst localhost:5000/hello:v1
+
+Example - Back up to a tar archive split into volumes of 4 GiB, written as hello.tar.001, hello.tar.002, ... and hello.tar.volumes.json:
+  oras backup --output hello.tar --volume-size 4GiB localhost:5000/hello
+
+Example - Back up to a tar archive streamed to stdout:
+  oras backup --output - localhost:5000/hello:v1 | ssh backup-host "cat > hello.tar"
+
 Example - Back up an artifact along with its referrers (e.g. attestations, SBOMs):
   oras backup --output hello --include-referrers localhost:5000/hello:v1
 
@@ -96,6 +149,12 @@ Example - Back up multiple specific tags:
 Example - Back up all tagged artifacts in a repository:
   oras backup --output hello localhost:5000/hello
 
+Example - Back up all tagged artifacts in all repositories under a namespace, listed via the catalog API:
+  oras backup --output team.tar localhost:5000/team/
+
+Example - Back up all tagged artifacts in all repositories of a registry:
+  oras backup --output all.tar localhost:5000/
+
 Example - Use Referrers API for discovering referrers:
   oras backup --output hello --include-referrers --distribution-spec v1.1-referrers-api localhost:5000/hello:v1
 
@@ -110,6 +169,24 @@ Example - Back up from a registry using plain HTTP (no TLS):
 
 Example - Set custom concurrency level:
   oras backup --output hello --concurrency 6 localhost:5000/hello:v1
+
+Example - Back up only the content missing in the previous full backup:
+  oras backup --output hello-mon.tar --incremental-from hello-full.tar localhost:5000/hello
+
+Example - Back up only the content missing in a chain of previous backups, listed from the full backup to the latest one:
+  oras backup --output hello-tue.tar --incremental-from hello-full.tar,hello-mon.tar localhost:5000/hello
+
+Example - Back up all tagged artifacts, resuming from the checkpoint of an interrupted run:
+  oras backup --output hello.tar --checkpoint hello.checkpoint localhost:5000/hello
+
+Example - Back up the tags of releases matching a regular expression, excluding release candidates:
+  oras backup --output hello.tar --tag-regex 'v[0-9]+\..*' --exclude-tag '*-rc*' localhost:5000/hello
+
+Example - Back up the tags of semantic versions of 1.2.0 or later within 1.x:
+  oras backup --output hello.tar --tag-semver '>=1.2.0, <2.0.0' localhost:5000/hello
+
+Example - Back up the artifacts created in the last 30 days:
+  oras backup --output hello.tar --since 30d localhost:5000/hello
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifacts to back up"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -119,19 +196,67 @@ Example - Set custom concurrency level:
 
 			// parse repo and references
 			var err error
-			opts.repository, opts.tags, err = parseArtifactReferences(args[0])
-			if err != nil {
+			if strings.HasSuffix(args[0], "/") {
+				// back up all repositories under the namespace
+				opts.allRepositories = true
+				opts.repository = args[0]
+				if opts.hostname, opts.namespace, err = repository.ParseRemoteRepository(args[0]); err != nil {
+					return fmt.Errorf("invalid namespace %q: %w", args[0], err)
+				}
+			} else {
+				opts.repository, opts.tags, err = parseArtifactReferences(args[0])
+				if err != nil {
+					return err
+				}
+			}
+
+			// parse tag filters
+			if opts.tagFilter, err = newTagFilter(opts.tagRegex, opts.tagSemver, opts.excludeTags, opts.since, time.Now()); err != nil {
 				return err
 			}
+			if opts.tagFilter != nil && len(opts.tags) > 0 {
+				return &oerrors.Error{
+					Err:            errors.New("--tag-regex, --tag-semver, --exclude-tag and --since cannot be used when tags are specified"),
+					Recommendation: fmt.Sprintf("Remove the tags from %q to filter all tags of the repository", args[0]),
+				}
+			}
 
 			// parse output format
-			if strings.EqualFold(filepath.Ext(opts.output), ".tar") {
-				opts.outputFormat = outputFormatTar
-			} else {
-				opts.outputFormat = outputFormatDir
+			if err := parseBackupOutputFormat(&opts); err != nil {
+				return err
+			}
+			if opts.volumeSizeFlag != "" {
+				if opts.volumeSize, err = humanize.ParseBytes(opts.volumeSizeFlag); err != nil {
+					return fmt.Errorf("invalid volume size: %w", err)
+				}
+				if opts.volumeSize <= 0 {
+					return fmt.Errorf("invalid volume size %q: must be positive", opts.volumeSizeFlag)
+				}
+				if opts.outputFormat != outputFormatTar || opts.output == "-" {
+					return &oerrors.Error{
+						Err:            errors.New("--volume-size can only be used when backing up to a tar archive file"),
+						Recommendation: `Specify a tar archive as the output, e.g. "--output backup.tar"`,
+					}
+				}
+			}
+			if opts.output == "-" {
+				if opts.checkpoint != "" {
+					return &oerrors.Error{
+						Err:            errors.New("--checkpoint cannot be used when backing up to stdout"),
+						Recommendation: "Back up to a file to resume an interrupted backup",
+					}
+				}
+				// keep stdout for the tar archive
+				opts.Printer = output.NewPrinter(cmd.ErrOrStderr(), cmd.ErrOrStderr())
 			}
 
-			opts.DisableTTY(opts.Debug, false)
+			for _, path := range opts.incrementalFrom {
+				if filepath.Clean(path) == filepath.Clean(opts.output) {
+					return fmt.Errorf("the output path %q cannot be one of the previous backups", opts.output)
+				}
+			}
+
+			opts.DisableTTY(opts.Debug, opts.output == "-")
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -141,18 +266,51 @@ Example - Set custom concurrency level:
 	}
 
 	// required flags
-	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "path to the target output, either a tar archive (*.tar) or a directory")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "path to the target output, either a tar archive (*.tar, *.tar.gz, *.tar.zst) or a directory, use - to write a tar archive to stdout")
 	_ = cmd.MarkFlagRequired("output")
 	// optional flags
 	cmd.Flags().BoolVarP(&opts.includeReferrers, "include-referrers", "", false, "back up the artifact with its referrers (e.g., attestations, SBOMs)")
-	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level, which is always 1 when streaming a tar archive")
+	cmd.Flags().StringVarP(&opts.checkpoint, "checkpoint", "", "", "[Experimental] path to a checkpoint file recording completed content and tags, used to resume an interrupted backup")
+	cmd.Flags().StringSliceVarP(&opts.incrementalFrom, "incremental-from", "", nil, "[Experimental] path to previous backups, either tar archives or directories, listed from the full backup to the latest one. Blobs in the previous backups are not backed up again")
+	cmd.Flags().StringVarP(&opts.volumeSizeFlag, "volume-size", "", "", "[Experimental] split the output tar archive into volumes of the given size, e.g. 4GiB, written as <output>.001, <output>.002, ... with a volume manifest <output>.volumes.json listing their checksums")
+	cmd.Flags().StringVarP(&opts.tagRegex, "tag-regex", "", "", "[Experimental] back up only the tags fully matching the regular expression")
+	cmd.Flags().StringVarP(&opts.tagSemver, "tag-semver", "", "", "[Experimental] back up only the tags of semantic versions satisfying the constraint, e.g. \">=1.2.0, <2.0.0\"")
+	cmd.Flags().StringSliceVarP(&opts.excludeTags, "exclude-tag", "", nil, "[Experimental] exclude the tags matching the glob patterns, e.g. \"*-rc*\"")
+	cmd.Flags().StringVarP(&opts.since, "since", "", "", "[Experimental] back up only the artifacts created within the time window, either a duration such as 72h or 30d, or an RFC 3339 timestamp. The creation time is read from the \"org.opencontainers.image.created\" annotation or the image config, and artifacts of unknown creation time are backed up")
+	cmd.Flags().StringVarP(&opts.compressionName, "compression", "", "", "[Experimental] compression of the output tar archive, options: none, gzip, zstd. Defaults to the one implied by the file extension of the output path")
 	opts.EnableDistributionSpecFlag()
 	// apply flags
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.AddCommand(backupVerifyCmd())
 	return oerrors.Command(cmd, &opts.Remote)
 }
 
-func runBackup(cmd *cobra.Command, opts *backupOptions) error {
+// parseBackupOutputFormat determines the output format and the compression of
+// the backup by the output path and the --compression flag.
+func parseBackupOutputFormat(opts *backupOptions) error {
+	compression, isTar := orasio.CompressionFromExt(opts.output)
+	if opts.output == "-" {
+		// stream a tar archive to stdout
+		compression, isTar = orasio.CompressionNone, true
+	}
+	if opts.compressionName != "" {
+		var err error
+		if compression, err = orasio.ParseCompression(opts.compressionName); err != nil {
+			return err
+		}
+		isTar = true
+	}
+	if !isTar {
+		opts.outputFormat = outputFormatDir
+		return nil
+	}
+	opts.outputFormat = outputFormatTar
+	opts.compression = compression
+	return nil
+}
+
+func runBackup(cmd *cobra.Command, opts *backupOptions) (returnErr error) {
 	if opts.output == "" {
 		return errors.New("the output path cannot be empty")
 	}
@@ -160,73 +318,131 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 
 	var dstRoot string
+	var stream *backupStream
 	switch opts.outputFormat {
 	case outputFormatDir:
 		dstRoot = opts.output
 	case outputFormatTar:
-		// test if the output file can be created and fail early if there is an issue
-		fp, err := os.OpenFile(opts.output, os.O_CREATE|os.O_WRONLY, 0666)
-		if err != nil {
-			if fi, statErr := os.Stat(opts.output); statErr == nil && fi.IsDir() {
-				return &oerrors.Error{
-					Err:            fmt.Errorf("the output path %q already exists and is a directory", opts.output),
-					Recommendation: "To back up to a tar archive, please specify a different output file name or remove the existing directory.",
-				}
+		if opts.checkpoint == "" {
+			// stream the backup into the tar archive
+			var err error
+			if stream, err = newBackupStream(cmd.OutOrStdout(), opts); err != nil {
+				return err
 			}
-			return fmt.Errorf("unable to create output file %s: %w", opts.output, err)
+			defer func() {
+				if returnErr != nil {
+					stream.abort(logger)
+				}
+			}()
+			break
 		}
-		if err := fp.Close(); err != nil {
-			return fmt.Errorf("unable to close output file %s: %w", opts.output, err)
+
+		if opts.volumeSize == 0 {
+			// test if the output file can be created and fail early if there is an issue
+			fp, err := createBackupOutput(opts.output)
+			if err != nil {
+				return err
+			}
+			if err := fp.Close(); err != nil {
+				return fmt.Errorf("unable to close output file %s: %w", opts.output, err)
+			}
 		}
 
-		// create a temporary directory as the working directory for OCI store
-		tempDir, err := os.MkdirTemp("", "oras-backup-*")
+		// back up into a working directory to be resumed from, which is
+		// exported to the tar archive when the backup is completed
+		workDir, err := backupWorkDir(opts.checkpoint)
 		if err != nil {
-			return fmt.Errorf("failed to create temporary directory for backup: %w", err)
+			return err
 		}
 		defer func() {
-			if err := os.RemoveAll(tempDir); err != nil {
-				logger.Debugf("failed to remove temporary directory %s: %v", tempDir, err)
+			if returnErr != nil {
+				// keep the working directory to resume the backup
+				return
+			}
+			if err := os.RemoveAll(workDir); err != nil {
+				logger.Debugf("failed to remove working directory %s: %v", workDir, err)
 			}
 		}()
-		dstRoot = tempDir
+		dstRoot = workDir
 	default:
 		// this should not happen, just a safeguard
 		return fmt.Errorf("unsupported output format")
 	}
 
-	// Prepare copy source and destination
-	srcRepo, err := opts.NewRepository(opts.repository, opts.Common, logger)
-	if err != nil {
-		return fmt.Errorf("failed to prepare repository %s for backup: %w", opts.repository, err)
+	// Prepare copy destination
+	var dst oras.GraphTarget
+	var store oras.ReadOnlyGraphTarget
+	if stream != nil {
+		dst, store = stream.TarWriter, stream.TarWriter
+	} else {
+		dstOCI, err := oci.New(dstRoot)
+		if err != nil {
+			return fmt.Errorf("failed to prepare OCI store for backup: %w", err)
+		}
+		dst, store = dstOCI, dstOCI
 	}
-	dstOCI, err := oci.New(dstRoot)
-	if err != nil {
-		return fmt.Errorf("failed to prepare OCI store for backup: %w", err)
+	statusHandler, metadataHandler := display.NewBackupHandler(opts.Printer, opts.TTY, opts.repository, dst)
+	if stream != nil {
+		if err := metadataHandler.OnTarExporting(opts.output); err != nil {
+			return err
+		}
+	}
+	if len(opts.incrementalFrom) > 0 {
+		base := make([]content.ReadOnlyStorage, 0, len(opts.incrementalFrom))
+		for _, path := range opts.incrementalFrom {
+			layout, _, closer, err := openOCILayout(ctx, path)
+			if err != nil {
+				return fmt.Errorf("failed to open previous backup: %w", err)
+			}
+			defer func() {
+				_ = closer.Close()
+			}()
+			base = append(base, layout)
+		}
+		dst = &incrementalTarget{
+			GraphTarget: dst,
+			base:        base,
+		}
+	}
+
+	var progress *checkpoint.Checkpoint
+	if opts.checkpoint != "" {
+		var err error
+		progress, err = openCheckpoint(opts.checkpoint, backupOperation(opts))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = progress.Close() }()
 	}
-	statusHandler, metadataHandler := display.NewBackupHandler(opts.Printer, opts.TTY, opts.repository, dstOCI)
 
 	// Resolve tags to back up
-	tags, roots, err := resolveTags(ctx, srcRepo, opts.tags)
+	items, err := resolveBackupItems(ctx, opts, logger, progress)
 	if err != nil {
 		return err
 	}
-	if len(tags) == 0 {
-		return &oerrors.Error{
-			Err:            fmt.Errorf("no tags found in repository %q", opts.repository),
-			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags"`, opts.repository),
-		}
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.name
 	}
-	if err := metadataHandler.OnTagsFound(tags); err != nil {
+	if err := metadataHandler.OnTagsFound(names); err != nil {
 		return err
 	}
 
 	// Prepare copy options
 	copyGraphOpts := oras.DefaultCopyGraphOptions
 	copyGraphOpts.Concurrency = opts.concurrency
+	if stream != nil {
+		// blobs are streamed one by one as the entries of a tar archive are
+		// sequential
+		copyGraphOpts.Concurrency = 1
+	}
 	copyGraphOpts.PreCopy = statusHandler.PreCopy
 	copyGraphOpts.PostCopy = statusHandler.PostCopy
 	copyGraphOpts.OnCopySkipped = statusHandler.OnCopySkipped
+	if progress != nil {
+		checkpoint.Hook(&copyGraphOpts, progress)
+		dst = checkpoint.NewTarget(dst, progress)
+	}
 	extCopyGraphOpts := oras.ExtendedCopyGraphOptions{
 		CopyGraphOptions: copyGraphOpts,
 		FindPredecessors: func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
@@ -234,9 +450,18 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 		},
 	}
 
-	for i, tag := range tags {
+	for _, item := range items {
+		tag := item.name
+		if progress != nil {
+			if done, ok := progress.Tag(tag); ok && done.Descriptor.Digest == item.root.Digest {
+				if err := metadataHandler.OnArtifactSkipped(tag, done.ReferrerCount); err != nil {
+					return err
+				}
+				continue
+			}
+		}
 		referrerCount, err := func() (referrerCount int, retErr error) {
-			trackedDst, err := statusHandler.StartTracking(dstOCI)
+			trackedDst, err := statusHandler.StartTracking(dst)
 			if err != nil {
 				return 0, err
 			}
@@ -248,23 +473,340 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 			}()
 
 			if opts.includeReferrers {
-				return backupTagWithReferrers(ctx, srcRepo, trackedDst, tag, roots[i], extCopyGraphOpts)
+				return backupTagWithReferrers(ctx, item.src, trackedDst, tag, item.root, extCopyGraphOpts)
 			}
-			return 0, backupTag(ctx, srcRepo, trackedDst, tag, roots[i], copyGraphOpts)
+			return 0, backupTag(ctx, item.src, trackedDst, tag, item.root, copyGraphOpts)
 		}()
 		if err != nil {
-			return fmt.Errorf("failed to back up tag %q from %q to %q: %w", tag, opts.repository, dstRoot, oerrors.UnwrapCopyError(err))
+			return fmt.Errorf("failed to back up tag %q from %q to %q: %w", tag, item.repository, opts.output, oerrors.UnwrapCopyError(err))
+		}
+		if progress != nil {
+			if err := progress.TagDone(checkpoint.Tag{
+				Name:          tag,
+				Descriptor:    item.root,
+				ReferrerCount: referrerCount,
+			}); err != nil {
+				return err
+			}
 		}
 		if err := metadataHandler.OnArtifactPulled(tag, referrerCount); err != nil {
 			return err
 		}
 	}
 
-	if err := finalizeBackupOutput(dstRoot, opts, logger, metadataHandler); err != nil {
+	// record the backed up artifacts in the inventory
+	inventoryJSON, err := buildBackupInventory(ctx, opts, store, items)
+	if err != nil {
 		return err
 	}
+	if stream != nil {
+		if err := stream.WriteFile(inventory.FileName, inventoryJSON); err != nil {
+			return fmt.Errorf("failed to write inventory: %w", err)
+		}
+	} else {
+		if err := os.WriteFile(filepath.Join(dstRoot, inventory.FileName), inventoryJSON, 0666); err != nil {
+			return fmt.Errorf("failed to write inventory: %w", err)
+		}
+	}
+
+	if stream != nil {
+		var annotations map[string]string
+		if len(opts.incrementalFrom) > 0 {
+			base, err := backupBaseAnnotation(opts.output, opts.incrementalFrom)
+			if err != nil {
+				return err
+			}
+			annotations = map[string]string{annotationBackupBase: base}
+		}
+		size, err := stream.close(annotations)
+		if err != nil {
+			return err
+		}
+		if stream.volumes != nil {
+			err = metadataHandler.OnVolumesExported(orasio.VolumeManifestPath(opts.output), len(stream.volumes.Volumes()), size)
+		} else {
+			err = metadataHandler.OnTarExported(opts.output, size)
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		if len(opts.incrementalFrom) > 0 {
+			if err := annotateBackupBase(dstRoot, opts.output, opts.incrementalFrom); err != nil {
+				return err
+			}
+		}
+		if err := finalizeBackupOutput(dstRoot, opts, logger, metadataHandler); err != nil {
+			return err
+		}
+	}
+	if progress != nil {
+		// the backup is completed, nothing is left to be resumed
+		if err := progress.Remove(); err != nil {
+			return err
+		}
+	}
 	duration := time.Since(startTime)
-	return metadataHandler.OnBackupCompleted(len(tags), opts.output, duration)
+	return metadataHandler.OnBackupCompleted(len(items), opts.output, duration)
+}
+
+// backupItem is an artifact to be backed up.
+type backupItem struct {
+	// src is the repository of the artifact.
+	src        oras.ReadOnlyGraphTarget
+	repository string
+	// name is the reference name of the artifact in the backup, which is the
+	// tag, or <repository>:<tag> if all repositories under a namespace are
+	// backed up.
+	name string
+	root ocispec.Descriptor
+}
+
+// resolveBackupItems resolves the artifacts to be backed up. Tags recorded as
+// completed in progress are resolved from the checkpoint.
+func resolveBackupItems(ctx context.Context, opts *backupOptions, logger logrus.FieldLogger, progress *checkpoint.Checkpoint) ([]backupItem, error) {
+	if !opts.allRepositories {
+		items, err := resolveRepositoryItems(ctx, opts, logger, progress, opts.repository, "", opts.tags)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			if opts.tagFilter != nil {
+				return nil, &oerrors.Error{
+					Err:            fmt.Errorf("no tags in repository %q match the filters", opts.repository),
+					Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags"`, opts.repository),
+				}
+			}
+			return nil, &oerrors.Error{
+				Err:            fmt.Errorf("no tags found in repository %q", opts.repository),
+				Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags"`, opts.repository),
+			}
+		}
+		return items, nil
+	}
+
+	reg, err := opts.NewRegistry(opts.hostname, opts.Common, logger)
+	if err != nil {
+		return nil, err
+	}
+	var repos []string
+	if err := reg.Repositories(ctx, "", func(got []string) error {
+		for _, repo := range got {
+			if strings.HasPrefix(repo, opts.namespace) {
+				repos = append(repos, repo)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list repositories under %q: %w", opts.repository, err)
+	}
+	var items []backupItem
+	for _, repo := range repos {
+		repoItems, err := resolveRepositoryItems(ctx, opts, logger, progress, opts.hostname+"/"+repo, repo+":", nil)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, repoItems...)
+	}
+	if len(items) == 0 {
+		return nil, &oerrors.Error{
+			Err:            fmt.Errorf("no tags found in repositories under %q", opts.repository),
+			Recommendation: fmt.Sprintf(`If you want to list available repositories under %q, use "oras repo ls"`, opts.repository),
+		}
+	}
+	return items, nil
+}
+
+// resolveRepositoryItems resolves the specified tags, or all tags if not
+// specified, of the repository. The reference names of the artifacts are the
+// tags prefixed with namePrefix.
+func resolveRepositoryItems(ctx context.Context, opts *backupOptions, logger logrus.FieldLogger, progress *checkpoint.Checkpoint, repository, namePrefix string, specifiedTags []string) ([]backupItem, error) {
+	srcRepo, err := opts.NewRepository(repository, opts.Common, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare repository %s for backup: %w", repository, err)
+	}
+	var resolver oras.ReadOnlyTarget = srcRepo
+	if progress != nil {
+		resolver = &checkpointedResolver{
+			ReadOnlyTarget: srcRepo,
+			TagLister:      srcRepo,
+			progress:       progress,
+			prefix:         namePrefix,
+		}
+	}
+	if opts.tagFilter != nil {
+		// filter the tags by name before resolving them
+		tags, err := registry.Tags(ctx, srcRepo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tags: %w", err)
+		}
+		if specifiedTags = opts.tagFilter.filterTags(tags); len(specifiedTags) == 0 {
+			logger.Debugf("no tags in %s match the filters", repository)
+			return nil, nil
+		}
+	}
+	tags, roots, err := resolveTags(ctx, resolver, specifiedTags)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]backupItem, 0, len(tags))
+	for i, tag := range tags {
+		if opts.tagFilter != nil {
+			matched, known, err := opts.tagFilter.matchCreated(ctx, srcRepo, roots[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to determine the creation time of tag %q: %w", tag, err)
+			}
+			if !known {
+				logger.Warnf("creation time of tag %q in %s is unknown, backing it up", tag, repository)
+			}
+			if !matched {
+				logger.Debugf("skipping tag %q in %s created before %s", tag, repository, opts.tagFilter.since)
+				continue
+			}
+		}
+		items = append(items, backupItem{
+			src:        srcRepo,
+			repository: repository,
+			name:       namePrefix + tag,
+			root:       roots[i],
+		})
+	}
+	return items, nil
+}
+
+// buildBackupInventory builds the inventory of the backed up artifacts in
+// store. Content not in store, such as the configs not kept by a streamed tar
+// archive or the content in the previous backups, is fetched from the source.
+func buildBackupInventory(ctx context.Context, opts *backupOptions, store oras.ReadOnlyGraphTarget, items []backupItem) ([]byte, error) {
+	inv := inventory.New(opts.repository, version.GetVersion(), time.Now().UTC())
+	for _, item := range items {
+		target := contentutil.NewLayeredTarget(store, item.src)
+		if err := inv.Add(ctx, target, item.name, item.root, opts.includeReferrers); err != nil {
+			return nil, fmt.Errorf("failed to build inventory for tag %q: %w", item.name, err)
+		}
+	}
+	inventoryJSON, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal inventory: %w", err)
+	}
+	return inventoryJSON, nil
+}
+
+// backupWorkDir returns the working directory for resumably backing up to a
+// tar archive, which is placed next to the checkpoint file so that it survives
+// an interrupted backup.
+func backupWorkDir(checkpointPath string) (string, error) {
+	workDir := checkpointPath + ".oci"
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create working directory for backup: %w", err)
+	}
+	return workDir, nil
+}
+
+// backupOperation returns the operation recorded in the checkpoint of a backup.
+func backupOperation(opts *backupOptions) string {
+	source := opts.repository
+	if len(opts.tags) > 0 {
+		source += ":" + strings.Join(opts.tags, ",")
+	}
+	operation := fmt.Sprintf("backup %s %s", source, opts.output)
+	if opts.includeReferrers {
+		operation += " --include-referrers"
+	}
+	return operation
+}
+
+// incrementalTarget is a GraphTarget reporting blobs in the previous backups
+// as existing, so that only the missing blobs are written. Manifests are always
+// written so that the backup can be tagged and referrers can be discovered.
+type incrementalTarget struct {
+	oras.GraphTarget
+	base []content.ReadOnlyStorage
+}
+
+// Exists returns true if the content exists in the target, or if it is a blob
+// existing in any previous backup.
+func (t *incrementalTarget) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	if !descriptor.IsManifest(target) {
+		for _, s := range t.base {
+			if exists, err := s.Exists(ctx, target); err != nil || exists {
+				return exists, err
+			}
+		}
+	}
+	return t.GraphTarget.Exists(ctx, target)
+}
+
+// backupBaseAnnotation returns the value of the annotation recording the
+// previous backups of an incremental backup, which are the comma-separated
+// paths relative to the output path.
+func backupBaseAnnotation(output string, bases []string) (string, error) {
+	outputDir, err := filepath.Abs(filepath.Dir(output))
+	if err != nil {
+		return "", err
+	}
+	relBases := make([]string, 0, len(bases))
+	for _, base := range bases {
+		absBase, err := filepath.Abs(base)
+		if err != nil {
+			return "", err
+		}
+		relBase, err := filepath.Rel(outputDir, absBase)
+		if err != nil {
+			relBase = absBase
+		}
+		relBases = append(relBases, filepath.ToSlash(relBase))
+	}
+	return strings.Join(relBases, ","), nil
+}
+
+// annotateBackupBase records the previous backups in the index.json of the
+// incremental backup at root. Paths are recorded relative to the output path.
+func annotateBackupBase(root, output string, bases []string) error {
+	base, err := backupBaseAnnotation(output, bases)
+	if err != nil {
+		return err
+	}
+
+	indexPath := filepath.Join(root, ocispec.ImageIndexFile)
+	indexJSON, err := os.ReadFile(indexPath)
+	if err != nil {
+		return fmt.Errorf("failed to read index of the backup: %w", err)
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(indexJSON, &index); err != nil {
+		return fmt.Errorf("failed to parse index of the backup: %w", err)
+	}
+	if index.Annotations == nil {
+		index.Annotations = make(map[string]string)
+	}
+	index.Annotations[annotationBackupBase] = base
+	if indexJSON, err = json.Marshal(index); err != nil {
+		return err
+	}
+	if err := os.WriteFile(indexPath, indexJSON, 0666); err != nil {
+		return fmt.Errorf("failed to write index of the backup: %w", err)
+	}
+	return nil
+}
+
+// checkpointedResolver resolves the tags recorded as completed in the
+// checkpoint without querying the repository.
+type checkpointedResolver struct {
+	oras.ReadOnlyTarget
+	registry.TagLister
+	progress *checkpoint.Checkpoint
+	// prefix is the prefix of the tag names recorded in the checkpoint.
+	prefix string
+}
+
+// Resolve resolves the tag recorded in the checkpoint, or falls back to the
+// underlying target.
+func (r *checkpointedResolver) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	if tag, ok := r.progress.Tag(r.prefix + reference); ok {
+		return tag.Descriptor, nil
+	}
+	return r.ReadOnlyTarget.Resolve(ctx, reference)
 }
 
 // backupTag copies the artifact identified by the tag from src to dst.
@@ -283,7 +825,21 @@ func backupTagWithReferrers(ctx context.Context, src oras.ReadOnlyGraphTarget, d
 	if err := recursiveCopy(ctx, src, dst, tag, root, extCopyGraphOpts); err != nil {
 		return 0, err
 	}
-	return countReferrers(ctx, dst, tag, root, extCopyGraphOpts)
+	referrerCount, err := countReferrers(ctx, dst, tag, root, extCopyGraphOpts)
+	if err != nil {
+		return 0, err
+	}
+	// record the number of referrers to be verified against
+	annotated := root
+	annotated.Annotations = maps.Clone(root.Annotations)
+	if annotated.Annotations == nil {
+		annotated.Annotations = make(map[string]string)
+	}
+	annotated.Annotations[annotationBackupReferrers] = strconv.Itoa(referrerCount)
+	if err := dst.Tag(ctx, annotated, tag); err != nil {
+		return 0, fmt.Errorf("failed to tag %q with %q: %w", root.Digest.String(), tag, err)
+	}
+	return referrerCount, nil
 }
 
 // countReferrers counts the total number of referrers for the given artifact identified by tag, including the referrers
@@ -332,6 +888,9 @@ func finalizeBackupOutput(dstRoot string, opts *backupOptions, logger logrus.Fie
 	if err := metadataHandler.OnTarExporting(opts.output); err != nil {
 		return err
 	}
+	if opts.volumeSize > 0 {
+		return exportBackupVolumes(dstRoot, opts, logger, metadataHandler)
+	}
 	tarFile, err := os.Create(opts.output)
 	if err != nil {
 		return fmt.Errorf("failed to create output file %s: %w", opts.output, err)
@@ -342,7 +901,7 @@ func finalizeBackupOutput(dstRoot string, opts *backupOptions, logger logrus.Fie
 			returnErr = err
 		}
 	}()
-	if err := orasio.TarDirectory(tarFile, dstRoot); err != nil {
+	if err := writeBackupTar(tarFile, dstRoot, opts.compression); err != nil {
 		// remove the output file in case of error
 		if err := os.Remove(opts.output); err != nil && !errors.Is(err, fs.ErrNotExist) {
 			logger.Debugf("failed to remove output file %s: %v", opts.output, err)
@@ -356,6 +915,156 @@ func finalizeBackupOutput(dstRoot string, opts *backupOptions, logger logrus.Fie
 	return metadataHandler.OnTarExported(opts.output, fi.Size())
 }
 
+// exportBackupVolumes exports the backup at dstRoot to a tar archive split
+// into volumes.
+func exportBackupVolumes(dstRoot string, opts *backupOptions, logger logrus.FieldLogger, metadataHandler metadata.BackupHandler) error {
+	vw, err := orasio.NewVolumeWriter(opts.output, opts.volumeSize)
+	if err != nil {
+		return err
+	}
+	counter := &byteCounter{w: vw}
+	if err := writeBackupTar(counter, dstRoot, opts.compression); err == nil {
+		err = vw.Close()
+	}
+	if err != nil {
+		if err := vw.Remove(); err != nil {
+			logger.Debugf("failed to remove volumes of %s: %v", opts.output, err)
+		}
+		return fmt.Errorf("failed to create tar archive at %s: %w", opts.output, err)
+	}
+	return metadataHandler.OnVolumesExported(orasio.VolumeManifestPath(opts.output), len(vw.Volumes()), counter.n)
+}
+
+// createBackupOutput creates the output file of a tar backup.
+func createBackupOutput(path string) (*os.File, error) {
+	fp, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		if fi, statErr := os.Stat(path); statErr == nil && fi.IsDir() {
+			return nil, &oerrors.Error{
+				Err:            fmt.Errorf("the output path %q already exists and is a directory", path),
+				Recommendation: "To back up to a tar archive, please specify a different output file name or remove the existing directory.",
+			}
+		}
+		return nil, fmt.Errorf("unable to create output file %s: %w", path, err)
+	}
+	return fp, nil
+}
+
+// backupStream streams a backup into the output tar archive, which is either
+// a file or the standard output.
+type backupStream struct {
+	*contentutil.TarWriter
+	// file is the output file, or nil if the output is the standard output or
+	// split into volumes.
+	file *os.File
+	// volumes writes the volumes if the output is split into volumes.
+	volumes *orasio.VolumeWriter
+	path    string
+	cw      io.WriteCloser
+	counter *byteCounter
+}
+
+// newBackupStream creates a backupStream writing to the output path of opts,
+// or to stdout if the output path is "-".
+func newBackupStream(stdout io.Writer, opts *backupOptions) (*backupStream, error) {
+	stream := &backupStream{path: opts.output}
+	w := stdout
+	switch {
+	case opts.volumeSize > 0:
+		vw, err := orasio.NewVolumeWriter(opts.output, opts.volumeSize)
+		if err != nil {
+			return nil, err
+		}
+		stream.volumes = vw
+		w = vw
+	case opts.output != "-":
+		fp, err := createBackupOutput(opts.output)
+		if err != nil {
+			return nil, err
+		}
+		stream.file = fp
+		w = fp
+	}
+	stream.counter = &byteCounter{w: w}
+	cw, err := orasio.NewCompressWriter(stream.counter, opts.compression)
+	if err != nil {
+		if stream.file != nil {
+			_ = stream.file.Close()
+		}
+		return nil, err
+	}
+	stream.cw = cw
+	stream.TarWriter = contentutil.NewTarWriter(cw)
+	return stream, nil
+}
+
+// close completes the tar archive with the index annotations and returns the
+// number of bytes written.
+func (s *backupStream) close(annotations map[string]string) (int64, error) {
+	if err := s.TarWriter.Close(annotations); err != nil {
+		return 0, fmt.Errorf("failed to write tar archive to %s: %w", s.path, err)
+	}
+	if err := s.cw.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write tar archive to %s: %w", s.path, err)
+	}
+	if s.file != nil {
+		if err := s.file.Close(); err != nil {
+			return 0, fmt.Errorf("failed to close output file %s: %w", s.path, err)
+		}
+		s.file = nil
+	}
+	if s.volumes != nil {
+		if err := s.volumes.Close(); err != nil {
+			return 0, err
+		}
+	}
+	return s.counter.n, nil
+}
+
+// abort removes the incomplete output file or volumes.
+func (s *backupStream) abort(logger logrus.FieldLogger) {
+	if s.volumes != nil {
+		if err := s.volumes.Remove(); err != nil {
+			logger.Debugf("failed to remove volumes of %s: %v", s.path, err)
+		}
+		return
+	}
+	if s.file == nil {
+		return
+	}
+	_ = s.file.Close()
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Debugf("failed to remove output file %s: %v", s.path, err)
+	}
+}
+
+// byteCounter counts the bytes written to w.
+type byteCounter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeBackupTar writes the OCI layout at dstRoot to w as a tar archive
+// compressed with compression.
+func writeBackupTar(w io.Writer, dstRoot string, compression orasio.Compression) (returnErr error) {
+	cw, err := orasio.NewCompressWriter(w, compression)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cw.Close(); returnErr == nil {
+			returnErr = err
+		}
+	}()
+	return orasio.TarDirectory(cw, dstRoot)
+}
+
 // resolveTags resolves tags to their descriptors.
 // It returns the resolved tags and their corresponding descriptors.
 func resolveTags(ctx context.Context, target oras.ReadOnlyTarget, specifiedTags []string) ([]string, []ocispec.Descriptor, error) {
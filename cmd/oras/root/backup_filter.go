@@ -0,0 +1,205 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/docker"
+)
+
+// tagFilter selects the tags to be backed up.
+type tagFilter struct {
+	// regex fully matches the tags to be included.
+	regex *regexp.Regexp
+	// semver is the constraint of the tags to be included, which are
+	// semantic versions.
+	semver *semver.Constraints
+	// exclude are the glob patterns of the tags to be excluded.
+	exclude []string
+	// since excludes the artifacts created before it if not zero.
+	since time.Time
+}
+
+// newTagFilter creates a tagFilter from the flag values. It returns nil if no
+// filter is specified.
+func newTagFilter(regex, semverConstraint string, exclude []string, since string, now time.Time) (*tagFilter, error) {
+	if regex == "" && semverConstraint == "" && len(exclude) == 0 && since == "" {
+		return nil, nil
+	}
+	var filter tagFilter
+	var err error
+	if regex != "" {
+		if filter.regex, err = regexp.Compile("^(?:" + regex + ")$"); err != nil {
+			return nil, fmt.Errorf("invalid tag regular expression %q: %w", regex, err)
+		}
+	}
+	if semverConstraint != "" {
+		if filter.semver, err = semver.NewConstraint(semverConstraint); err != nil {
+			return nil, fmt.Errorf("invalid semantic version constraint %q: %w", semverConstraint, err)
+		}
+	}
+	for _, pattern := range exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tag pattern %q: %w", pattern, err)
+		}
+	}
+	filter.exclude = exclude
+	if since != "" {
+		if filter.since, err = parseSince(since, now); err != nil {
+			return nil, err
+		}
+	}
+	return &filter, nil
+}
+
+// parseSince parses the start of a time window, which is either a timestamp
+// in RFC 3339 format, or a duration before now such as "72h" or "30d".
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	var duration time.Duration
+	if days, ok := strings.CutSuffix(since, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time window %q: %w", since, err)
+		}
+		duration = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if duration, err = time.ParseDuration(since); err != nil {
+			return time.Time{}, fmt.Errorf("invalid time window %q: %w", since, err)
+		}
+	}
+	if duration < 0 {
+		return time.Time{}, fmt.Errorf("invalid time window %q: must not be negative", since)
+	}
+	return now.Add(-duration), nil
+}
+
+// matchTag returns true if the name of the tag is selected.
+func (f *tagFilter) matchTag(tag string) bool {
+	for _, pattern := range f.exclude {
+		if matched, _ := path.Match(pattern, tag); matched {
+			return false
+		}
+	}
+	if f.regex != nil && !f.regex.MatchString(tag) {
+		return false
+	}
+	if f.semver != nil {
+		version, err := semver.NewVersion(tag)
+		if err != nil || !f.semver.Check(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterTags returns the tags with selected names.
+func (f *tagFilter) filterTags(tags []string) []string {
+	var selected []string
+	for _, tag := range tags {
+		if f.matchTag(tag) {
+			selected = append(selected, tag)
+		}
+	}
+	return selected
+}
+
+// matchCreated returns true if the artifact described by root is created
+// within the time window. known is false if the creation time of the artifact
+// cannot be determined, in which case the artifact is selected.
+func (f *tagFilter) matchCreated(ctx context.Context, fetcher content.Fetcher, root ocispec.Descriptor) (matched bool, known bool, err error) {
+	if f.since.IsZero() {
+		return true, true, nil
+	}
+	created, known, err := artifactCreated(ctx, fetcher, root)
+	if err != nil || !known {
+		return true, known, err
+	}
+	return !created.Before(f.since), true, nil
+}
+
+// artifactCreated returns the creation time of the artifact described by desc,
+// read from the "org.opencontainers.image.created" annotation of the manifest,
+// or from the config of an image. The creation time of an index without the
+// annotation is the latest one of its manifests.
+func artifactCreated(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) (time.Time, bool, error) {
+	if !descriptor.IsManifest(desc) {
+		return time.Time{}, false, nil
+	}
+	manifestJSON, err := content.FetchAll(ctx, fetcher, desc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to fetch %s: %w", desc.Digest, err)
+	}
+	var manifest struct {
+		Config      *ocispec.Descriptor  `json:"config"`
+		Manifests   []ocispec.Descriptor `json:"manifests"`
+		Annotations map[string]string    `json:"annotations"`
+	}
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+	}
+	if value, ok := manifest.Annotations[ocispec.AnnotationCreated]; ok {
+		if created, err := time.Parse(time.RFC3339, value); err == nil {
+			return created, true, nil
+		}
+	}
+
+	if descriptor.IsIndex(desc) {
+		var latest time.Time
+		var known bool
+		for _, child := range manifest.Manifests {
+			created, ok, err := artifactCreated(ctx, fetcher, child)
+			if err != nil {
+				return time.Time{}, false, err
+			}
+			if ok && created.After(latest) {
+				latest, known = created, true
+			}
+		}
+		return latest, known, nil
+	}
+
+	if manifest.Config == nil || docker.ConvertMediaType(manifest.Config.MediaType) != ocispec.MediaTypeImageConfig {
+		return time.Time{}, false, nil
+	}
+	configJSON, err := content.FetchAll(ctx, fetcher, *manifest.Config)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to fetch config %s: %w", manifest.Config.Digest, err)
+	}
+	var config ocispec.Image
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to parse config %s: %w", manifest.Config.Digest, err)
+	}
+	if config.Created == nil {
+		return time.Time{}, false, nil
+	}
+	return *config.Created, true, nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func Test_newTagFilter(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		regex     string
+		semver    string
+		exclude   []string
+		since     string
+		wantNil   bool
+		wantSince time.Time
+		wantErr   bool
+	}{
+		{name: "no filter", wantNil: true},
+		{name: "regex", regex: "v[0-9]+.*"},
+		{name: "invalid regex", regex: "[", wantErr: true},
+		{name: "semver", semver: ">=1.2.0, <2.0.0"},
+		{name: "invalid semver", semver: "not a version", wantErr: true},
+		{name: "exclude", exclude: []string{"*-rc*"}},
+		{name: "invalid exclude", exclude: []string{"["}, wantErr: true},
+		{name: "since days", since: "30d", wantSince: now.Add(-30 * 24 * time.Hour)},
+		{name: "since duration", since: "72h", wantSince: now.Add(-72 * time.Hour)},
+		{name: "since timestamp", since: "2025-01-01T00:00:00Z", wantSince: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "invalid since", since: "last month", wantErr: true},
+		{name: "invalid since days", since: "xd", wantErr: true},
+		{name: "negative since", since: "-1h", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTagFilter(tt.regex, tt.semver, tt.exclude, tt.since, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newTagFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == nil) != tt.wantNil {
+				t.Fatalf("newTagFilter() = %v, wantNil %v", got, tt.wantNil)
+			}
+			if got != nil && !got.since.Equal(tt.wantSince) {
+				t.Errorf("newTagFilter() since = %v, want %v", got.since, tt.wantSince)
+			}
+		})
+	}
+}
+
+func Test_tagFilter_filterTags(t *testing.T) {
+	tags := []string{"latest", "v1.0.0", "v1.2.0", "v1.3.0-rc.1", "v2.0.0", "nightly-20250630"}
+	tests := []struct {
+		name    string
+		regex   string
+		semver  string
+		exclude []string
+		want    []string
+	}{
+		{
+			name:  "regex",
+			regex: "v1\\..*",
+			want:  []string{"v1.0.0", "v1.2.0", "v1.3.0-rc.1"},
+		},
+		{
+			name:  "regex partial match",
+			regex: "v1\\.[0-9]",
+			want:  nil,
+		},
+		{
+			name:   "semver",
+			semver: ">=1.2.0, <2.0.0",
+			want:   []string{"v1.2.0"},
+		},
+		{
+			name:    "exclude",
+			exclude: []string{"*-rc*", "nightly-*"},
+			want:    []string{"latest", "v1.0.0", "v1.2.0", "v2.0.0"},
+		},
+		{
+			name:    "combined",
+			regex:   "v.*",
+			semver:  ">=1.2.0-0",
+			exclude: []string{"v2.*"},
+			want:    []string{"v1.2.0", "v1.3.0-rc.1"},
+		},
+		{
+			name:  "no match",
+			regex: "release-.*",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newTagFilter(tt.regex, tt.semver, tt.exclude, "", time.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filter.filterTags(tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tagFilter_matchCreated(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := since.Add(-24 * time.Hour)
+	recent := since.Add(24 * time.Hour)
+
+	pushJSON := func(mediaType string, v any) ocispec.Descriptor {
+		t.Helper()
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc := content.NewDescriptorFromBytes(mediaType, b)
+		if err := store.Push(ctx, desc, bytes.NewReader(b)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	annotated := func(created time.Time) ocispec.Descriptor {
+		t.Helper()
+		return pushJSON(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+			Versioned:   specs.Versioned{SchemaVersion: 2},
+			MediaType:   ocispec.MediaTypeImageManifest,
+			Config:      ocispec.DescriptorEmptyJSON,
+			Layers:      []ocispec.Descriptor{},
+			Annotations: map[string]string{ocispec.AnnotationCreated: created.Format(time.RFC3339)},
+		})
+	}
+	image := func(created *time.Time) ocispec.Descriptor {
+		t.Helper()
+		config := pushJSON(ocispec.MediaTypeImageConfig, ocispec.Image{Created: created})
+		return pushJSON(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    config,
+			Layers:    []ocispec.Descriptor{},
+		})
+	}
+	index := func(manifests ...ocispec.Descriptor) ocispec.Descriptor {
+		t.Helper()
+		return pushJSON(ocispec.MediaTypeImageIndex, ocispec.Index{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageIndex,
+			Manifests: manifests,
+		})
+	}
+
+	unknown := pushJSON(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.test",
+		Config:       ocispec.DescriptorEmptyJSON,
+		Layers:       []ocispec.Descriptor{},
+	})
+	filter := &tagFilter{since: since}
+	tests := []struct {
+		name        string
+		root        ocispec.Descriptor
+		wantMatched bool
+		wantKnown   bool
+	}{
+		{name: "recent annotation", root: annotated(recent), wantMatched: true, wantKnown: true},
+		{name: "old annotation", root: annotated(old), wantMatched: false, wantKnown: true},
+		{name: "recent image config", root: image(&recent), wantMatched: true, wantKnown: true},
+		{name: "old image config", root: image(&old), wantMatched: false, wantKnown: true},
+		{name: "image config without created", root: image(nil), wantMatched: true, wantKnown: false},
+		{name: "index with a recent manifest", root: index(image(&old), image(&recent)), wantMatched: true, wantKnown: true},
+		{name: "index with old manifests", root: index(image(&old), annotated(old)), wantMatched: false, wantKnown: true},
+		{name: "unknown", root: unknown, wantMatched: true, wantKnown: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, known, err := filter.matchCreated(ctx, store, tt.root)
+			if err != nil {
+				t.Fatalf("matchCreated() error = %v", err)
+			}
+			if matched != tt.wantMatched || known != tt.wantKnown {
+				t.Errorf("matchCreated() = %v, %v, want %v, %v", matched, known, tt.wantMatched, tt.wantKnown)
+			}
+		})
+	}
+
+	// no time window
+	if matched, _, err := (&tagFilter{}).matchCreated(ctx, store, annotated(old)); err != nil || !matched {
+		t.Errorf("matchCreated() without time window = %v, %v, want true", matched, err)
+	}
+}
@@ -16,10 +16,12 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -32,11 +34,19 @@ import (
 	"github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
 	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/checkpoint"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/inventory"
+	orasio "oras.land/oras/internal/io"
 )
 
 func TestParseArtifactReferences(t *testing.T) {
@@ -741,6 +751,11 @@ func (m *mockBackupHandler) OnTarExported(path string, size int64) error {
 	return m.tarExportedResult
 }
 
+func (m *mockBackupHandler) OnVolumesExported(manifestPath string, volumeCount int, size int64) error {
+	m.tarExportedCalled = true
+	return m.tarExportedResult
+}
+
 func (m *mockBackupHandler) OnTagsFound(tags []string) error {
 	return nil
 }
@@ -749,6 +764,10 @@ func (m *mockBackupHandler) OnArtifactPulled(tag string, referrerCount int) erro
 	return nil
 }
 
+func (m *mockBackupHandler) OnArtifactSkipped(tag string, referrerCount int) error {
+	return nil
+}
+
 func (m *mockBackupHandler) OnBackupCompleted(tagsCount int, path string, duration time.Duration) error {
 	return nil
 }
@@ -756,3 +775,569 @@ func (m *mockBackupHandler) OnBackupCompleted(tagsCount int, path string, durati
 func (m *mockBackupHandler) Render() error {
 	return nil
 }
+
+func Test_resolveTags_checkpointed(t *testing.T) {
+	ctx := context.Background()
+	repoName := "test/repo"
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447",
+		Size:      456,
+	}
+	done := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:d5b7c742df27379894518554b73f7a3a03b4440ea435151a8b525a8d2555a0b2",
+		Size:      123,
+	}
+
+	var requested []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Header().Set("Content-Type", desc.MediaType)
+		w.Header().Set("Docker-Content-Digest", desc.Digest.String())
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(make([]byte, desc.Size))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	repo, err := remote.NewRepository(strings.TrimPrefix(server.URL, "http://") + "/" + repoName)
+	if err != nil {
+		t.Fatalf("failed to create remote repository: %v", err)
+	}
+	repo.PlainHTTP = true
+
+	progress, err := openCheckpoint(filepath.Join(t.TempDir(), "checkpoint"), "backup test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer progress.Close()
+	if err := progress.TagDone(checkpoint.Tag{Name: "v1", Descriptor: done, ReferrerCount: 2}); err != nil {
+		t.Fatal(err)
+	}
+	resolver := &checkpointedResolver{
+		ReadOnlyTarget: repo,
+		TagLister:      repo,
+		progress:       progress,
+	}
+
+	tags, descs, err := resolveTags(ctx, resolver, []string{"v1", "v2"})
+	if err != nil {
+		t.Fatalf("resolveTags() error = %v", err)
+	}
+	if !reflect.DeepEqual(tags, []string{"v1", "v2"}) {
+		t.Errorf("resolveTags() tags = %v, want %v", tags, []string{"v1", "v2"})
+	}
+	if want := []ocispec.Descriptor{done, desc}; !reflect.DeepEqual(descs, want) {
+		t.Errorf("resolveTags() descs = %v, want %v", descs, want)
+	}
+	if want := []string{"/v2/" + repoName + "/manifests/v2"}; !reflect.DeepEqual(requested, want) {
+		t.Errorf("requested = %v, want %v", requested, want)
+	}
+}
+
+func Test_backupOperation(t *testing.T) {
+	opts := &backupOptions{
+		output:     "hello.tar",
+		repository: "localhost:5000/hello",
+		tags:       []string{"v1", "v2"},
+	}
+	if got, want := backupOperation(opts), "backup localhost:5000/hello:v1,v2 hello.tar"; got != want {
+		t.Errorf("backupOperation() = %q, want %q", got, want)
+	}
+	opts.tags = nil
+	opts.includeReferrers = true
+	if got, want := backupOperation(opts), "backup localhost:5000/hello hello.tar --include-referrers"; got != want {
+		t.Errorf("backupOperation() = %q, want %q", got, want)
+	}
+}
+
+func Test_incrementalBackup(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	pushBlob := func(blob []byte) ocispec.Descriptor {
+		t.Helper()
+		desc := content.NewDescriptorFromBytes("application/vnd.test.layer", blob)
+		if err := src.Push(ctx, desc, strings.NewReader(string(blob))); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	oldLayer := pushBlob([]byte("old"))
+	newLayer := pushBlob([]byte("new"))
+	v1, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: []ocispec.Descriptor{oldLayer}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: []ocispec.Descriptor{oldLayer, newLayer}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// full backup of v1
+	dir := t.TempDir()
+	fullPath := filepath.Join(dir, "full")
+	full, err := oci.New(fullPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backupTag(ctx, src, full, "v1", v1, oras.DefaultCopyGraphOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	// incremental backup of v2
+	incPath := filepath.Join(dir, "inc")
+	inc, err := oci.New(incPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := &incrementalTarget{GraphTarget: inc, base: []content.ReadOnlyStorage{full}}
+	if err := backupTag(ctx, src, dst, "v2", v2, oras.DefaultCopyGraphOptions); err != nil {
+		t.Fatalf("backupTag() error = %v", err)
+	}
+	for _, tt := range []struct {
+		desc ocispec.Descriptor
+		want bool
+	}{
+		{v2, true},
+		{newLayer, true},
+		{oldLayer, false},
+	} {
+		if exists, err := inc.Exists(ctx, tt.desc); err != nil || exists != tt.want {
+			t.Errorf("Exists(%s) in incremental backup = %v, %v, want %v", tt.desc.Digest, exists, err, tt.want)
+		}
+	}
+
+	if err := annotateBackupBase(incPath, incPath, []string{fullPath}); err != nil {
+		t.Fatalf("annotateBackupBase() error = %v", err)
+	}
+	indexJSON, err := os.ReadFile(filepath.Join(incPath, ocispec.ImageIndexFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(indexJSON, &index); err != nil {
+		t.Fatal(err)
+	}
+	if got := index.Annotations[annotationBackupBase]; got != "full" {
+		t.Errorf("annotation %s = %q, want %q", annotationBackupBase, got, "full")
+	}
+	if len(index.Manifests) != 1 || index.Manifests[0].Annotations[ocispec.AnnotationRefName] != "v2" {
+		t.Errorf("tags of the incremental backup are not kept: %v", index.Manifests)
+	}
+
+	// restore from the chain
+	incLayout, _, incCloser, err := openOCILayout(ctx, incPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer incCloser.Close()
+	fullLayout, _, fullCloser, err := openOCILayout(ctx, fullPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fullCloser.Close()
+	restored := memory.New()
+	if _, err := oras.Copy(ctx, contentutil.NewLayeredTarget(incLayout, fullLayout), "v2", restored, "v2", oras.DefaultCopyOptions); err != nil {
+		t.Fatalf("failed to restore from the chain: %v", err)
+	}
+	if exists, err := restored.Exists(ctx, oldLayer); err != nil || !exists {
+		t.Error("blobs in the full backup should be restored")
+	}
+}
+
+func Test_parseBackupOutputFormat(t *testing.T) {
+	tests := []struct {
+		name            string
+		output          string
+		compression     string
+		wantFormat      outputFormat
+		wantCompression orasio.Compression
+		wantErr         bool
+	}{
+		{name: "directory", output: "backup", wantFormat: outputFormatDir},
+		{name: "tar", output: "backup.tar", wantFormat: outputFormatTar, wantCompression: orasio.CompressionNone},
+		{name: "tar.gz", output: "backup.tar.gz", wantFormat: outputFormatTar, wantCompression: orasio.CompressionGzip},
+		{name: "tgz", output: "backup.tgz", wantFormat: outputFormatTar, wantCompression: orasio.CompressionGzip},
+		{name: "flag overrides extension", output: "backup.tar", compression: "gzip", wantFormat: outputFormatTar, wantCompression: orasio.CompressionGzip},
+		{name: "flag without extension", output: "backup", compression: "none", wantFormat: outputFormatTar, wantCompression: orasio.CompressionNone},
+		{name: "tar.zst", output: "backup.tar.zst", wantFormat: outputFormatTar, wantCompression: orasio.CompressionZstd},
+		{name: "tar.zstd", output: "backup.tar.zstd", wantFormat: outputFormatTar, wantCompression: orasio.CompressionZstd},
+		{name: "zstd flag", output: "backup.tar", compression: "zstd", wantFormat: outputFormatTar, wantCompression: orasio.CompressionZstd},
+		{name: "unknown flag", output: "backup.tar", compression: "bzip2", wantErr: true},
+		{name: "stdout", output: "-", wantFormat: outputFormatTar, wantCompression: orasio.CompressionNone},
+		{name: "stdout with compression", output: "-", compression: "gzip", wantFormat: outputFormatTar, wantCompression: orasio.CompressionGzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &backupOptions{output: tt.output, compressionName: tt.compression}
+			err := parseBackupOutputFormat(opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBackupOutputFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.outputFormat != tt.wantFormat || opts.compression != tt.wantCompression {
+				t.Errorf("parseBackupOutputFormat() = (%v, %q), want (%v, %q)", opts.outputFormat, opts.compression, tt.wantFormat, tt.wantCompression)
+			}
+		})
+	}
+}
+
+func Test_backupStream(t *testing.T) {
+	ctx := context.Background()
+	src, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{Subject: &root}); err != nil {
+		t.Fatal(err)
+	}
+	extCopyGraphOpts := oras.ExtendedCopyGraphOptions{
+		FindPredecessors: func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+			return registry.Referrers(ctx, src, desc, "")
+		},
+	}
+	backup := func(t *testing.T, stdout io.Writer, opts *backupOptions) int64 {
+		t.Helper()
+		stream, err := newBackupStream(stdout, opts)
+		if err != nil {
+			t.Fatalf("newBackupStream() error = %v", err)
+		}
+		referrerCount, err := backupTagWithReferrers(ctx, src, stream, "v1", root, extCopyGraphOpts)
+		if err != nil {
+			t.Fatalf("backupTagWithReferrers() error = %v", err)
+		}
+		if referrerCount != 1 {
+			t.Errorf("backupTagWithReferrers() = %d, want 1", referrerCount)
+		}
+		size, err := stream.close(map[string]string{annotationBackupBase: "full.tar"})
+		if err != nil {
+			t.Fatalf("close() error = %v", err)
+		}
+		return size
+	}
+	verify := func(t *testing.T, path string) {
+		t.Helper()
+		layout, _, closer, err := openOCILayout(ctx, path)
+		if err != nil {
+			t.Fatalf("openOCILayout() error = %v", err)
+		}
+		defer closer.Close()
+		got, err := layout.Resolve(ctx, "v1")
+		if err != nil || got.Digest != root.Digest {
+			t.Fatalf("Resolve() = %v, %v, want %v", got, err, root)
+		}
+		referrers, err := registry.Referrers(ctx, layout, root, "")
+		if err != nil || len(referrers) != 1 {
+			t.Errorf("Referrers() = %v, %v, want 1 referrer", referrers, err)
+		}
+	}
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "backup.tar.gz")
+		size := backup(t, nil, &backupOptions{output: path, compression: orasio.CompressionGzip})
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Size() != size {
+			t.Errorf("close() size = %d, want %d", size, fi.Size())
+		}
+		verify(t, path)
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		var stdout bytes.Buffer
+		size := backup(t, &stdout, &backupOptions{output: "-"})
+		if int64(stdout.Len()) != size {
+			t.Errorf("close() size = %d, want %d", size, stdout.Len())
+		}
+		path := filepath.Join(t.TempDir(), "backup.tar")
+		if err := os.WriteFile(path, stdout.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		verify(t, path)
+	})
+
+	t.Run("volumes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "backup.tar")
+		size := backup(t, nil, &backupOptions{output: path, volumeSize: 1024})
+		manifest, err := orasio.ReadVolumeManifest(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if manifest.Size() != size || len(manifest.Volumes) < 2 {
+			t.Fatalf("volumes = %+v, want %d bytes in multiple volumes", manifest.Volumes, size)
+		}
+		verify(t, orasio.VolumePath(path, 0))
+
+		// corrupt a volume
+		if err := os.WriteFile(orasio.VolumePath(path, 1), make([]byte, 1024), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, _, _, err = openOCILayout(ctx, orasio.VolumePath(path, 0))
+		if !errors.Is(err, orasio.ErrInvalidVolume) {
+			t.Errorf("openOCILayout() error = %v, want %v", err, orasio.ErrInvalidVolume)
+		}
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "backup.tar")
+		stream, err := newBackupStream(nil, &backupOptions{output: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream.abort(logrus.New())
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("incomplete output should be removed, got %v", err)
+		}
+	})
+
+	t.Run("output is a directory", func(t *testing.T) {
+		if _, err := newBackupStream(nil, &backupOptions{output: t.TempDir()}); err == nil {
+			t.Error("newBackupStream() error = nil, want error")
+		}
+	})
+}
+
+func Test_backupInventory(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{Subject: &root}); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "backup")
+	dst, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backupTagWithReferrers(ctx, src, dst, "v1", root, oras.DefaultExtendedCopyGraphOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	// build the inventory
+	opts := &backupOptions{
+		repository:       "localhost:5000/hello",
+		includeReferrers: true,
+	}
+	items := []backupItem{{src: src, repository: opts.repository, name: "v1", root: root}}
+	inventoryJSON, err := buildBackupInventory(ctx, opts, dst, items)
+	if err != nil {
+		t.Fatalf("buildBackupInventory() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, inventory.FileName), inventoryJSON, 0666); err != nil {
+		t.Fatal(err)
+	}
+	tarPath := dir + ".tar.gz"
+	fp, err := os.Create(tarPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeBackupTar(fp, dir, orasio.CompressionGzip); err != nil {
+		t.Fatal(err)
+	}
+	if err := fp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// read the inventory back
+	for _, path := range []string{dir, tarPath} {
+		inv, err := readBackupInventory(path)
+		if err != nil {
+			t.Fatalf("readBackupInventory(%s) error = %v", path, err)
+		}
+		if inv.Source != opts.repository || inv.OrasVersion == "" || inv.Size == 0 {
+			t.Errorf("readBackupInventory(%s) = %+v, want source %s with version and size", path, inv, opts.repository)
+		}
+		want := []inventory.Tag{{
+			Name:      "v1",
+			Digest:    root.Digest,
+			MediaType: root.MediaType,
+			Referrers: map[string]int{"application/vnd.test.sbom": 1},
+		}}
+		if !reflect.DeepEqual(inv.Tags, want) {
+			t.Errorf("readBackupInventory(%s) tags = %+v, want %+v", path, inv.Tags, want)
+		}
+	}
+
+	// backups without an inventory
+	if err := os.Remove(filepath.Join(dir, inventory.FileName)); err != nil {
+		t.Fatal(err)
+	}
+	var oerr *oerrors.Error
+	if _, err := readBackupInventory(dir); !errors.As(err, &oerr) {
+		t.Errorf("readBackupInventory() error = %v, want %T", err, oerr)
+	}
+}
+
+func Test_resolveBackupItems_namespace(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	desc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestJSON, err := content.FetchAll(ctx, src, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags := map[string][]string{
+		"team/app":     {"v1", "v2"},
+		"team/lib":     {"latest"},
+		"team/empty":   {},
+		"teammate/app": {"v1"},
+		"other/app":    {"v1"},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Docker-Distribution-Api-Version", "registry/2.0")
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/v2/_catalog", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"repositories":["other/app","team/app","team/empty","team/lib","teammate/app"]}`))
+	})
+	for repo, repoTags := range tags {
+		mux.HandleFunc(fmt.Sprintf("/v2/%s/tags/list", repo), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": repo, "tags": repoTags})
+		})
+		for _, ref := range append([]string{desc.Digest.String()}, repoTags...) {
+			mux.HandleFunc(fmt.Sprintf("/v2/%s/manifests/%s", repo, ref), func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", desc.MediaType)
+				w.Header().Set("Docker-Content-Digest", desc.Digest.String())
+				w.Header().Set("Content-Length", fmt.Sprint(desc.Size))
+				w.WriteHeader(http.StatusOK)
+				if r.Method == http.MethodGet {
+					_, _ = w.Write(manifestJSON)
+				}
+			})
+		}
+		mux.HandleFunc(fmt.Sprintf("/v2/%s/blobs/%s", repo, ocispec.DescriptorEmptyJSON.Digest), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Docker-Content-Digest", ocispec.DescriptorEmptyJSON.Digest.String())
+			_, _ = w.Write(ocispec.DescriptorEmptyJSON.Data)
+		})
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	newOptions := func(t *testing.T, namespace string) *backupOptions {
+		t.Helper()
+		opts := &backupOptions{
+			repository:      host + "/" + namespace,
+			allRepositories: true,
+			hostname:        host,
+			namespace:       namespace,
+		}
+		fs := pflag.NewFlagSet("backup", pflag.ContinueOnError)
+		opts.Remote.ApplyFlags(fs)
+		if err := fs.Parse([]string{"--plain-http"}); err != nil {
+			t.Fatal(err)
+		}
+		return opts
+	}
+	logger := logrus.New()
+
+	t.Run("repositories under the namespace", func(t *testing.T) {
+		items, err := resolveBackupItems(ctx, newOptions(t, "team/"), logger, nil)
+		if err != nil {
+			t.Fatalf("resolveBackupItems() error = %v", err)
+		}
+		// repositories out of the namespace, including the ones sharing the
+		// prefix without a separator, are not backed up
+		want := []struct {
+			repository string
+			name       string
+		}{
+			{repository: host + "/team/app", name: "team/app:v1"},
+			{repository: host + "/team/app", name: "team/app:v2"},
+			{repository: host + "/team/lib", name: "team/lib:latest"},
+		}
+		if len(items) != len(want) {
+			t.Fatalf("resolveBackupItems() = %d items, want %d", len(items), len(want))
+		}
+		for i, item := range items {
+			if item.repository != want[i].repository || item.name != want[i].name {
+				t.Errorf("item[%d] = (%q, %q), want (%q, %q)", i, item.repository, item.name, want[i].repository, want[i].name)
+			}
+			if item.root.Digest != desc.Digest {
+				t.Errorf("item[%d] digest = %v, want %v", i, item.root.Digest, desc.Digest)
+			}
+		}
+	})
+
+	t.Run("names in the archive", func(t *testing.T) {
+		items, err := resolveBackupItems(ctx, newOptions(t, "team/"), logger, nil)
+		if err != nil {
+			t.Fatalf("resolveBackupItems() error = %v", err)
+		}
+		dst, err := oci.New(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range items {
+			if err := backupTag(ctx, item.src, dst, item.name, item.root, oras.DefaultCopyGraphOptions); err != nil {
+				t.Fatalf("backupTag() error = %v", err)
+			}
+		}
+		got, err := registry.Tags(ctx, dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"team/app:v1", "team/app:v2", "team/lib:latest"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("tags in the backup = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("whole registry", func(t *testing.T) {
+		items, err := resolveBackupItems(ctx, newOptions(t, ""), logger, nil)
+		if err != nil {
+			t.Fatalf("resolveBackupItems() error = %v", err)
+		}
+		var names []string
+		for _, item := range items {
+			names = append(names, item.name)
+		}
+		want := []string{"other/app:v1", "team/app:v1", "team/app:v2", "team/lib:latest", "teammate/app:v1"}
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("resolveBackupItems() names = %v, want %v", names, want)
+		}
+	})
+
+	t.Run("tag filter", func(t *testing.T) {
+		opts := newOptions(t, "team/")
+		filter, err := newTagFilter(`v\d+`, "", []string{"v2"}, "", time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts.tagFilter = filter
+		items, err := resolveBackupItems(ctx, opts, logger, nil)
+		if err != nil {
+			t.Fatalf("resolveBackupItems() error = %v", err)
+		}
+		if len(items) != 1 || items[0].name != "team/app:v1" {
+			t.Errorf("resolveBackupItems() = %+v, want team/app:v1 only", items)
+		}
+	})
+
+	t.Run("no tags", func(t *testing.T) {
+		_, err := resolveBackupItems(ctx, newOptions(t, "team/empty/"), logger, nil)
+		var oerr *oerrors.Error
+		if !errors.As(err, &oerr) {
+			t.Errorf("resolveBackupItems() error = %v, want %T", err, oerr)
+		}
+	})
+}
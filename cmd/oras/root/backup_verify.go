@@ -0,0 +1,250 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/graph"
+)
+
+type backupVerifyOptions struct {
+	option.Common
+
+	// flags
+	incrementalFrom []string
+
+	// derived options
+	path string
+}
+
+func backupVerifyCmd() *cobra.Command {
+	var opts backupVerifyOptions
+	cmd := &cobra.Command{
+		Use:   "verify [flags] <path>",
+		Short: "[Experimental] Verify the integrity of a backup",
+		Long: `[Experimental] Verify the integrity of a backup, which can be either a directory, a tar archive or a volume of a split tar archive. No registry is accessed.
+
+Every tagged artifact in the backup is walked to check that all the referenced manifests and blobs are present and match their digests and sizes. For backups made with --include-referrers, the referrers found are also checked against the number of referrers recorded at backup time. A report is printed for each tag and the command fails if any tag fails the verification.
+
+Example - Verify a backup in a tar archive:
+  oras backup verify hello.tar
+
+Example - Verify a backup in a directory:
+  oras backup verify hello
+
+Example - Verify a backup split into volumes, given the first volume:
+  oras backup verify hello.tar.001
+
+Example - Verify an incremental backup with the previous backups in the chain, listed from the full backup:
+  oras backup verify --incremental-from hello-full.tar,hello-mon.tar hello-tue.tar
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the backup to verify"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.path = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackupVerify(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.incrementalFrom, "incremental-from", "", nil, "[Experimental] path to previous backups that the backup is incremental from, listed from the full backup to the latest one")
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+func runBackupVerify(cmd *cobra.Command, opts *backupVerifyOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+
+	// prepare the backup to verify, layered on the previous backups if any
+	target, _, closer, err := openOCILayout(ctx, opts.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	if len(opts.incrementalFrom) > 0 {
+		bases := make([]content.ReadOnlyStorage, 0, len(opts.incrementalFrom))
+		for i := len(opts.incrementalFrom) - 1; i >= 0; i-- {
+			base, _, closer, err := openOCILayout(ctx, opts.incrementalFrom[i])
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = closer.Close()
+			}()
+			bases = append(bases, base)
+		}
+		target = contentutil.NewLayeredTarget(target, bases...)
+	}
+
+	tags, roots, err := resolveTags(ctx, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list tags in %q: %w", opts.path, err)
+	}
+
+	metadataHandler := display.NewBackupVerifyHandler(opts.Printer)
+	verifier := newBackupVerifier(target)
+	var failedCount int
+	for i, tag := range tags {
+		logger.Debugf("verifying tag %q, digest %q", tag, roots[i].Digest)
+		result, err := verifier.verify(ctx, roots[i])
+		if err != nil {
+			return fmt.Errorf("failed to verify tag %q: %w", tag, err)
+		}
+		if len(result.problems) > 0 {
+			failedCount++
+			if err := metadataHandler.OnTagFailed(tag, result.problems); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := metadataHandler.OnTagVerified(tag, result.contentCount, result.referrerCount); err != nil {
+			return err
+		}
+	}
+	if err := metadataHandler.OnVerifyCompleted(opts.path, len(tags), failedCount); err != nil {
+		return err
+	}
+	if failedCount > 0 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%d of %d tag(s) in %q failed the verification", failedCount, len(tags), opts.path),
+			Recommendation: "Copy the backup again from its origin, or back up the failed tags again",
+		}
+	}
+	return nil
+}
+
+// backupVerifyResult is the result of verifying a tagged artifact.
+type backupVerifyResult struct {
+	// contentCount is the number of manifests and blobs checked.
+	contentCount int
+	// referrerCount is the number of referrers found.
+	referrerCount int
+	// problems are the problems found, empty if the artifact is intact.
+	problems []string
+}
+
+// backupVerifier verifies the tagged artifacts in a backup.
+type backupVerifier struct {
+	target oras.ReadOnlyGraphTarget
+	// checked caches the problem found in each content checked, which is
+	// empty if the content is intact. Content shared by tags is checked once.
+	checked map[digest.Digest]string
+}
+
+// newBackupVerifier creates a backupVerifier verifying the artifacts in
+// target.
+func newBackupVerifier(target oras.ReadOnlyGraphTarget) *backupVerifier {
+	return &backupVerifier{
+		target:  target,
+		checked: make(map[digest.Digest]string),
+	}
+}
+
+// verify walks the graph of root, including the referrers of root and, if root
+// is an index, the referrers of its manifests, which are the ones backed up
+// with --include-referrers.
+func (v *backupVerifier) verify(ctx context.Context, root ocispec.Descriptor) (backupVerifyResult, error) {
+	var result backupVerifyResult
+	walker := &graph.ArtifactWalker{
+		Storage:       v.target,
+		WithReferrers: true,
+		Visit: func(ctx context.Context, desc ocispec.Descriptor, referrer bool) (bool, error) {
+			result.contentCount++
+			if referrer {
+				result.referrerCount++
+			}
+			if problem := v.check(ctx, desc); problem != "" {
+				result.problems = append(result.problems, problem)
+				return false, nil
+			}
+			return true, nil
+		},
+		OnSuccessorsError: func(_ context.Context, desc ocispec.Descriptor, err error) error {
+			result.problems = append(result.problems, fmt.Sprintf("invalid manifest %s: %v", desc.Digest, err))
+			return nil
+		},
+	}
+	if err := walker.Walk(ctx, root); err != nil {
+		return backupVerifyResult{}, err
+	}
+
+	// compare with the number of referrers recorded at backup time
+	if value, ok := root.Annotations[annotationBackupReferrers]; ok {
+		if expected, err := strconv.Atoi(value); err == nil && result.referrerCount < expected {
+			result.problems = append(result.problems, fmt.Sprintf("missing referrers: %d referrer(s) backed up, %d found", expected, result.referrerCount))
+		}
+	}
+	return result, nil
+}
+
+// check checks that the content described by desc exists and matches its
+// digest and size, and returns the problem found if any.
+func (v *backupVerifier) check(ctx context.Context, desc ocispec.Descriptor) string {
+	if problem, ok := v.checked[desc.Digest]; ok {
+		return problem
+	}
+	problem := checkContent(ctx, v.target, desc)
+	v.checked[desc.Digest] = problem
+	return problem
+}
+
+// checkContent reads the content described by desc from fetcher and returns
+// the problem found if any.
+func checkContent(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) string {
+	kind := "blob"
+	if descriptor.IsManifest(desc) {
+		kind = "manifest"
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return fmt.Sprintf("missing %s %s (%s)", kind, desc.Digest, desc.MediaType)
+		}
+		return fmt.Sprintf("unreadable %s %s: %v", kind, desc.Digest, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+	vr := content.NewVerifyReader(rc, desc)
+	if _, err = io.Copy(io.Discard, vr); err == nil {
+		err = vr.Verify()
+	}
+	if err != nil {
+		return fmt.Sprintf("corrupted %s %s: %v", kind, desc.Digest, err)
+	}
+	return ""
+}
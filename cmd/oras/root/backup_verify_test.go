@@ -0,0 +1,198 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+	orasio "oras.land/oras/internal/io"
+)
+
+// prepareVerifyBackup backs up an artifact with a layer and a referrer into an
+// OCI layout directory.
+func prepareVerifyBackup(t *testing.T) (string, ocispec.Descriptor, ocispec.Descriptor) {
+	t.Helper()
+	ctx := context.Background()
+	src := memory.New()
+	layerContent := []byte("hello")
+	layer := content.NewDescriptorFromBytes("application/vnd.test.layer", layerContent)
+	if err := src.Push(ctx, layer, bytes.NewReader(layerContent)); err != nil {
+		t.Fatal(err)
+	}
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: []ocispec.Descriptor{layer}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	referrer, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{Subject: &root})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "backup")
+	dst, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	referrerCount, err := backupTagWithReferrers(ctx, src, dst, "v1", root, oras.DefaultExtendedCopyGraphOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if referrerCount != 1 {
+		t.Fatalf("backupTagWithReferrers() = %d, want 1", referrerCount)
+	}
+	return dir, layer, referrer
+}
+
+func blobFilePath(dir string, desc ocispec.Descriptor) string {
+	return filepath.Join(dir, ocispec.ImageBlobsDir, desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+}
+
+func Test_backupVerifier_verify(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(t *testing.T, dir string, layer, referrer ocispec.Descriptor)
+		wantProblems []string
+	}{
+		{
+			name:   "intact backup",
+			modify: func(*testing.T, string, ocispec.Descriptor, ocispec.Descriptor) {},
+		},
+		{
+			name: "missing blob",
+			modify: func(t *testing.T, dir string, layer, _ ocispec.Descriptor) {
+				if err := os.Remove(blobFilePath(dir, layer)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantProblems: []string{"missing blob"},
+		},
+		{
+			name: "corrupted blob",
+			modify: func(t *testing.T, dir string, layer, _ ocispec.Descriptor) {
+				if err := os.WriteFile(blobFilePath(dir, layer), []byte("world"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantProblems: []string{"corrupted blob"},
+		},
+		{
+			name: "truncated blob",
+			modify: func(t *testing.T, dir string, layer, _ ocispec.Descriptor) {
+				if err := os.WriteFile(blobFilePath(dir, layer), []byte("hel"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantProblems: []string{"corrupted blob"},
+		},
+		{
+			name: "missing referrer",
+			modify: func(t *testing.T, dir string, _, referrer ocispec.Descriptor) {
+				if err := os.Remove(blobFilePath(dir, referrer)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantProblems: []string{"missing referrers: 1 referrer(s) backed up, 0 found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dir, layer, referrer := prepareVerifyBackup(t)
+			tt.modify(t, dir, layer, referrer)
+
+			target, _, closer, err := openOCILayout(ctx, dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer closer.Close()
+			_, roots, err := resolveTags(ctx, target, []string{"v1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := newBackupVerifier(target).verify(ctx, roots[0])
+			if err != nil {
+				t.Fatalf("verify() error = %v", err)
+			}
+			if len(result.problems) != len(tt.wantProblems) {
+				t.Fatalf("verify() problems = %v, want %v", result.problems, tt.wantProblems)
+			}
+			for i, want := range tt.wantProblems {
+				if !strings.Contains(result.problems[i], want) {
+					t.Errorf("verify() problem = %q, want containing %q", result.problems[i], want)
+				}
+			}
+			if len(tt.wantProblems) == 0 && result.referrerCount != 1 {
+				t.Errorf("verify() referrerCount = %d, want 1", result.referrerCount)
+			}
+		})
+	}
+}
+
+func Test_runBackupVerify(t *testing.T) {
+	dir, layer, _ := prepareVerifyBackup(t)
+	tarPath := dir + ".tar"
+	fp, err := os.Create(tarPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeBackupTar(fp, dir, orasio.CompressionNone); err != nil {
+		t.Fatal(err)
+	}
+	if err := fp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(path string) (string, error) {
+		cmd := backupVerifyCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs([]string{path})
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	// verify the tar archive
+	out, err := run(tarPath)
+	if err != nil {
+		t.Fatalf("verify %s error = %v, output = %s", tarPath, err, out)
+	}
+	if !strings.Contains(out, "Verified tag v1:") || !strings.Contains(out, "with 1 referrer(s)") {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	// verify the directory with a missing blob
+	if err := os.Remove(blobFilePath(dir, layer)); err != nil {
+		t.Fatal(err)
+	}
+	out, err = run(dir)
+	if err == nil {
+		t.Fatalf("verify %s should fail, output = %s", dir, out)
+	}
+	if !strings.Contains(out, "Failed tag v1: 1 problem(s) found") || !strings.Contains(out, "missing blob "+layer.Digest.String()) {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
@@ -101,10 +101,13 @@ Example - Fetch and print a blob from OCI image layout archive file 'layout.tar'
 func fetchBlob(cmd *cobra.Command, opts *fetchBlobOptions) (fetchErr error) {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 	var target oras.ReadOnlyTarget
-	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	target, closer, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = closer.Close()
+	}()
 
 	if err := opts.EnsureReferenceNotEmpty(cmd, false); err != nil {
 		return err
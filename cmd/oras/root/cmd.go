@@ -19,6 +19,7 @@ import (
 	"github.com/spf13/cobra"
 	"oras.land/oras/cmd/oras/root/blob"
 	"oras.land/oras/cmd/oras/root/manifest"
+	"oras.land/oras/cmd/oras/root/referrers"
 	"oras.land/oras/cmd/oras/root/repo"
 )
 
@@ -40,8 +41,11 @@ func New() *cobra.Command {
 		attachCmd(),
 		backupCmd(),
 		restoreCmd(),
+		mirrorCmd(),
+		promoteCmd(),
 		blob.Cmd(),
 		manifest.Cmd(),
+		referrers.Cmd(),
 		repo.Cmd(),
 	)
 	return cmd
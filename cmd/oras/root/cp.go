@@ -16,15 +16,21 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"slices"
 	"strings"
 
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/registry"
@@ -33,9 +39,12 @@ import (
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/checkpoint"
+	"oras.land/oras/internal/contentutil"
 	"oras.land/oras/internal/docker"
 	"oras.land/oras/internal/graph"
 	"oras.land/oras/internal/listener"
@@ -44,21 +53,46 @@ import (
 
 type copyOptions struct {
 	option.Common
-	option.Platform
+	option.Platforms
 	option.BinaryTarget
 	option.Terminal
 
 	recursive   bool
 	concurrency int
 	extraRefs   []string
+	convert     string
+	toFile      string
+	failFast    bool
+	checkpoint  string
+
+	referrerTypes        []string
+	excludeReferrerTypes []string
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
+
+	// onConverted is called with the source and the converted descriptors
+	// when a manifest is converted during copy.
+	onConverted func(source, converted ocispec.Descriptor) error
+	// extraDestinations are the destinations other than To when copying to
+	// multiple destinations.
+	extraDestinations []copyDestination
+	// progress records the completed nodes if a checkpoint is specified.
+	progress *checkpoint.Checkpoint
+}
+
+// copyDestination is an additional destination of a fan-out copy.
+type copyDestination struct {
+	target    *option.Target
+	extraRefs []string
 }
 
+// convertFormatOCI indicates converting docker manifests to OCI manifests.
+const convertFormatOCI = "oci"
+
 func copyCmd() *cobra.Command {
 	var opts copyOptions
 	cmd := &cobra.Command{
-		Use:     "cp [flags] <from>{:<tag>|@<digest>} <to>[:<tag>[,<tag>][...]]",
+		Use:     "cp [flags] <from>{:<tag>|@<digest>} <to>[:<tag>[,<tag>][...]] [<to>[:<tag>[,<tag>][...]]...]",
 		Aliases: []string{"copy"},
 		Short:   "Copy artifacts from one target to another",
 		Long: `Copy artifacts from one target to another. When copying an image index, all of its manifests will be copied
@@ -78,6 +112,12 @@ Example - Upload an artifact from an OCI layout tar archive:
 Example - Copy an artifact and its referrers:
   oras cp -r localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 
+Example - Copy an artifact and only its signature referrers:
+  oras cp -r --referrer-type application/vnd.cncf.notary.signature localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact and its referrers except debug symbols:
+  oras cp -r --exclude-referrer-type application/vnd.example.debug-symbols localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
 Example - Copy an artifact and referrers using specific methods for the Referrers API:
   oras cp -r --from-distribution-spec v1.1-referrers-api --to-distribution-spec v1.1-referrers-tag \
     localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
@@ -85,22 +125,81 @@ Example - Copy an artifact and referrers using specific methods for the Referrer
 Example - Copy certain platform of an artifact:
   oras cp --platform linux/arm/v5 localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 
+Example - Copy a subset of platforms of a multi-arch image into a new image index:
+  oras cp --platform linux/amd64,linux/arm64 localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy a docker image and convert it to an OCI image:
+  oras cp --convert oci localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact and its referrers, resuming from the checkpoint of an interrupted run:
+  oras cp -r --checkpoint net-monitor.checkpoint localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
 Example - Copy an artifact with multiple tags:
   oras cp localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:tag1,tag2,tag3
 
 Example - Copy an artifact with multiple tags with concurrency tuned:
   oras cp --concurrency 10 localhost:5000/net-monitor:v1 localhost:5000/net-monitor-copy:tag1,tag2,tag3
+
+Example - Copy an artifact to multiple registries, reading the source only once:
+  oras cp localhost:5000/net-monitor:v1 localhost:6000/net-monitor:v1 localhost:7000/net-monitor:v1
+
+Example - Copy an artifact to destinations listed in a file, one per line:
+  oras cp --to-file destinations.txt localhost:5000/net-monitor:v1
+
+Example - Copy an artifact to multiple registries and stop at the first failure:
+  oras cp --fail-fast localhost:5000/net-monitor:v1 localhost:6000/net-monitor:v1 localhost:7000/net-monitor:v1
 `,
-		Args: oerrors.CheckArgs(argument.Exactly(2), "the source and destination for copying"),
+		Args: oerrors.CheckArgs(argument.AtLeast(1), "the source and destination for copying"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			opts.From.RawReference = args[0]
-			refs := strings.Split(args[1], ",")
+			destinations := args[1:]
+			if opts.toFile != "" {
+				fromFile, err := readCopyDestinations(opts.toFile)
+				if err != nil {
+					return err
+				}
+				destinations = append(destinations, fromFile...)
+			}
+			if len(destinations) == 0 {
+				return &oerrors.Error{
+					Err:            errors.New("no destination specified"),
+					Usage:          fmt.Sprintf("%s %s", cmd.Parent().CommandPath(), cmd.Use),
+					Recommendation: "Please specify the destination as an argument or list destinations in a file with --to-file.",
+				}
+			}
+			refs := strings.Split(destinations[0], ",")
 			opts.To.RawReference = refs[0]
 			opts.extraRefs = refs[1:]
 			err := option.Parse(cmd, &opts)
 			if err != nil {
 				return err
 			}
+			if opts.checkpoint != "" && len(destinations) > 1 {
+				return &oerrors.Error{
+					Err:            errors.New("--checkpoint cannot be used when copying to multiple destinations"),
+					Recommendation: "Please copy to each destination separately to resume the copies with checkpoints.",
+				}
+			}
+			for _, destination := range destinations[1:] {
+				refs := strings.Split(destination, ",")
+				target, err := opts.To.WithReference(refs[0])
+				if err != nil {
+					return err
+				}
+				opts.extraDestinations = append(opts.extraDestinations, copyDestination{
+					target:    target,
+					extraRefs: refs[1:],
+				})
+			}
+			if err := validateConvertFlag(&opts); err != nil {
+				return err
+			}
+			if !opts.recursive && (len(opts.referrerTypes) > 0 || len(opts.excludeReferrerTypes) > 0) {
+				return &oerrors.Error{
+					Err:            errors.New("--referrer-type and --exclude-referrer-type can only be used with --recursive"),
+					Recommendation: "Please add the --recursive flag to copy referrers.",
+				}
+			}
 			opts.DisableTTY(opts.Debug, false)
 			return nil
 		},
@@ -111,6 +210,12 @@ Example - Copy an artifact with multiple tags with concurrency tuned:
 	}
 	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "[Preview] recursively copy the artifact and its referrer artifacts")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	cmd.Flags().StringSliceVarP(&opts.referrerTypes, "referrer-type", "", nil, "[Experimental] only copy referrers of the given artifact types, used with --recursive")
+	cmd.Flags().StringSliceVarP(&opts.excludeReferrerTypes, "exclude-referrer-type", "", nil, "[Experimental] skip referrers of the given artifact types, used with --recursive")
+	cmd.Flags().StringVarP(&opts.checkpoint, "checkpoint", "", "", "[Experimental] path to a checkpoint file recording completed content, used to resume an interrupted copy")
+	cmd.Flags().StringVarP(&opts.toFile, "to-file", "", "", "[Experimental] path to a file listing destinations, one per line")
+	cmd.Flags().BoolVarP(&opts.failFast, "fail-fast", "", false, "[Experimental] abort copying to all destinations at the first failure of any destination")
+	cmd.Flags().StringVarP(&opts.convert, "convert", "", "", "[Experimental] convert docker manifests and manifest lists during copy, only `oci` is supported")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.EnableDistributionSpecFlag()
@@ -118,18 +223,82 @@ Example - Copy an artifact with multiple tags with concurrency tuned:
 	return oerrors.Command(cmd, &opts.BinaryTarget)
 }
 
+// validateConvertFlag validates the --convert flag and its combinations.
+func validateConvertFlag(opts *copyOptions) error {
+	switch {
+	case opts.convert == "":
+		return nil
+	case opts.convert != convertFormatOCI:
+		return &oerrors.Error{
+			Err:            fmt.Errorf("unsupported conversion format %q", opts.convert),
+			Recommendation: fmt.Sprintf("Only %q is supported for the --convert flag.", convertFormatOCI),
+		}
+	case opts.recursive:
+		return &oerrors.Error{
+			Err:            errors.New("--convert cannot be used with --recursive"),
+			Recommendation: "Referrers cannot be carried over since converted manifests have new digests. Please copy without --recursive.",
+		}
+	case len(opts.Platforms.Platforms) > 1:
+		return &oerrors.Error{
+			Err:            errors.New("--convert cannot be used with multiple platforms"),
+			Recommendation: "Please specify a single --platform to convert the manifest of that platform, or no --platform to convert the whole manifest list.",
+		}
+	}
+	return nil
+}
+
+// openCheckpoint opens the checkpoint file for the operation.
+func openCheckpoint(path, operation string) (*checkpoint.Checkpoint, error) {
+	progress, err := checkpoint.Open(path, operation)
+	if err != nil {
+		if errors.Is(err, checkpoint.ErrMismatch) {
+			return nil, &oerrors.Error{
+				Err:            err,
+				Recommendation: "Please specify a different checkpoint file, or remove the existing one to start over.",
+			}
+		}
+		return nil, err
+	}
+	return progress, nil
+}
+
+// readCopyDestinations reads destinations from a file, one per line. Empty
+// lines and lines starting with '#' are ignored.
+func readCopyDestinations(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read destinations: %w", err)
+	}
+	var destinations []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		destinations = append(destinations, line)
+	}
+	return destinations, nil
+}
+
 func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 
 	// Prepare source
-	src, err := opts.From.NewReadonlyTarget(ctx, opts.Common, logger)
+	src, closer, err := opts.From.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = closer.Close()
+	}()
 	if err := opts.EnsureSourceTargetReferenceNotEmpty(cmd); err != nil {
 		return err
 	}
 
+	if len(opts.extraDestinations) > 0 {
+		return runFanOutCopy(ctx, src, opts, logger)
+	}
+
 	// Prepare destination
 	dst, err := opts.To.NewTarget(opts.Common, logger)
 	if err != nil {
@@ -137,14 +306,28 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	}
 	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
 	statusHandler, metadataHandler := display.NewCopyHandler(opts.Printer, opts.TTY, dst)
+	opts.onConverted = metadataHandler.OnConverted
 
+	if opts.checkpoint != "" {
+		opts.progress, err = openCheckpoint(opts.checkpoint, fmt.Sprintf("cp %s %s", opts.From.RawReference, opts.To.RawReference))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = opts.progress.Close() }()
+	}
 	desc, err := doCopy(ctx, statusHandler, src, dst, opts)
 	if err != nil {
 		return err
 	}
+	if opts.progress != nil {
+		// the copy is completed, nothing is left to be resumed
+		if err := opts.progress.Remove(); err != nil {
+			return err
+		}
+	}
 
-	if from, err := digest.Parse(opts.From.Reference); err == nil && from != desc.Digest {
-		// correct source digest
+	if from, err := digest.Parse(opts.From.Reference); err == nil && from != desc.Digest && !opts.buildsNewRoot() {
+		// correct source digest, unless a new root is built
 		opts.From.RawReference = fmt.Sprintf("%s@%s", opts.From.Path, desc.Digest.String())
 	}
 
@@ -164,12 +347,134 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	return metadataHandler.Render()
 }
 
+// runFanOutCopy copies the source artifact to multiple destinations, reading
+// the source content only once. Failures of a destination do not abort the
+// others unless fail-fast is set.
+func runFanOutCopy(ctx context.Context, src oras.ReadOnlyGraphTarget, opts *copyOptions, logger logrus.FieldLogger) error {
+	// Prepare destinations
+	targets := []*option.Target{&opts.To}
+	extraRefs := [][]string{opts.extraRefs}
+	for _, destination := range opts.extraDestinations {
+		targets = append(targets, destination.target)
+		extraRefs = append(extraRefs, destination.extraRefs)
+	}
+	dsts := make([]oras.GraphTarget, len(targets))
+	tracked := make([]oras.GraphTarget, len(targets))
+	for i, target := range targets {
+		dst, err := target.NewTarget(opts.Common, logger)
+		if err != nil {
+			return err
+		}
+		ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
+		dsts[i] = dst
+		tracked[i] = &destinationStatusTarget{
+			GraphTarget: dst,
+			handler:     display.NewDestinationCopyHandler(opts.Printer, target.RawReference, dst),
+		}
+	}
+	fanOut := contentutil.NewFanOutTarget(opts.failFast, tracked...)
+	_, metadataHandler := display.NewCopyHandler(opts.Printer, opts.TTY, fanOut)
+	opts.onConverted = metadataHandler.OnConverted
+
+	// copy the content once, and tag each destination afterwards. The status
+	// is reported by each destination.
+	copyOpts := *opts
+	copyOpts.To.Reference = ""
+	desc, err := doCopy(ctx, status.NewDiscardHandler(), src, fanOut, &copyOpts)
+	if err != nil {
+		return err
+	}
+	if from, err := digest.Parse(opts.From.Reference); err == nil && from != desc.Digest && !opts.buildsNewRoot() {
+		// correct source digest, unless a new root is built
+		opts.From.RawReference = fmt.Sprintf("%s@%s", opts.From.Path, desc.Digest.String())
+	}
+
+	var failed int
+	for i, target := range targets {
+		binaryTarget := &option.BinaryTarget{From: opts.From, To: *target}
+		err := fanOut.Err(i)
+		if err == nil {
+			err = tagCopiedDestination(ctx, dsts[i], target.Reference, extraRefs[i], desc, opts.concurrency, metadataHandler, binaryTarget)
+		}
+		if err != nil {
+			if opts.failFast {
+				return err
+			}
+			failed++
+			if err := metadataHandler.OnCopyFailed(binaryTarget, err); err != nil {
+				return err
+			}
+		}
+	}
+	if err := metadataHandler.Render(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to copy to %d of %d destinations", failed, len(targets))
+	}
+	return nil
+}
+
+// destinationStatusTarget reports the copy status of a single destination of a
+// fan-out copy.
+type destinationStatusTarget struct {
+	oras.GraphTarget
+	handler status.CopyHandler
+}
+
+// Exists reports the content as skipped if it exists in the destination.
+func (t *destinationStatusTarget) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	exists, err := t.GraphTarget.Exists(ctx, target)
+	if err != nil || !exists {
+		return exists, err
+	}
+	return true, t.handler.OnCopySkipped(ctx, target)
+}
+
+// Push pushes the content to the destination and reports its status.
+func (t *destinationStatusTarget) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	if err := t.handler.PreCopy(ctx, expected); err != nil {
+		return err
+	}
+	if err := t.GraphTarget.Push(ctx, expected, content); err != nil {
+		return err
+	}
+	return t.handler.PostCopy(ctx, expected)
+}
+
+// tagCopiedDestination tags the copied root in a destination of a fan-out copy
+// and reports the destination as copied.
+func tagCopiedDestination(ctx context.Context, dst oras.GraphTarget, ref string, extraRefs []string, root ocispec.Descriptor, concurrency int, metadataHandler metadata.CopyHandler, target *option.BinaryTarget) error {
+	if dgst, err := digest.Parse(ref); err == nil {
+		if dgst != root.Digest {
+			return fmt.Errorf("the copied digest %s does not match the destination digest %s", root.Digest, dgst)
+		}
+	} else if err := tagCopiedRoot(ctx, dst, root, ref); err != nil {
+		return err
+	}
+	if err := metadataHandler.OnCopied(target, root); err != nil {
+		return err
+	}
+	if len(extraRefs) == 0 {
+		return nil
+	}
+	tagNOpts := oras.DefaultTagNOptions
+	tagNOpts.Concurrency = concurrency
+	tagListener := listener.NewTaggedListener(dst, metadataHandler.OnTagged)
+	_, err := oras.TagN(ctx, tagListener, root.Digest.String(), extraRefs, tagNOpts)
+	return err
+}
+
 func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, opts *copyOptions) (desc ocispec.Descriptor, err error) {
 	// Prepare copy options
 	extendedCopyGraphOptions := oras.DefaultExtendedCopyGraphOptions
 	extendedCopyGraphOptions.Concurrency = opts.concurrency
 	extendedCopyGraphOptions.FindPredecessors = func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
-		return registry.Referrers(ctx, src, desc, "")
+		referrers, err := registry.Referrers(ctx, src, desc, "")
+		if err != nil {
+			return nil, err
+		}
+		return opts.filterReferrers(referrers), nil
 	}
 
 	if mountRepo, canMount := getMountPoint(src, dst, opts); canMount {
@@ -191,9 +496,23 @@ func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOn
 	extendedCopyGraphOptions.PreCopy = copyHandler.PreCopy
 	extendedCopyGraphOptions.PostCopy = copyHandler.PostCopy
 	extendedCopyGraphOptions.OnMounted = copyHandler.OnMounted
+	if opts.progress != nil {
+		checkpoint.Hook(&extendedCopyGraphOptions.CopyGraphOptions, opts.progress)
+		dst = checkpoint.NewTarget(dst, opts.progress)
+	}
 
+	if len(opts.Platforms.Platforms) > 1 {
+		return copyPlatforms(ctx, copyHandler, src, dst, opts, extendedCopyGraphOptions)
+	}
+	var targetPlatform *ocispec.Platform
+	if len(opts.Platforms.Platforms) == 1 {
+		targetPlatform = opts.Platforms.Platforms[0]
+	}
 	rOpts := oras.DefaultResolveOptions
-	rOpts.TargetPlatform = opts.Platform.Platform
+	rOpts.TargetPlatform = targetPlatform
+	if opts.convert != "" {
+		return convertCopy(ctx, copyHandler, src, dst, opts, rOpts, extendedCopyGraphOptions.CopyGraphOptions)
+	}
 	if opts.recursive {
 		desc, err = oras.Resolve(ctx, src, opts.From.Reference, rOpts)
 		if err != nil {
@@ -211,8 +530,8 @@ func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOn
 			copyOptions := oras.CopyOptions{
 				CopyGraphOptions: extendedCopyGraphOptions.CopyGraphOptions,
 			}
-			if opts.Platform.Platform != nil {
-				copyOptions.WithTargetPlatform(opts.Platform.Platform)
+			if targetPlatform != nil {
+				copyOptions.WithTargetPlatform(targetPlatform)
 			}
 			desc, err = oras.Copy(ctx, src, opts.From.Reference, dst, opts.To.Reference, copyOptions)
 		}
@@ -221,6 +540,247 @@ func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOn
 	return desc, err
 }
 
+// copyPlatforms copies the manifests of the requested platforms in the source
+// image index, along with their referrers if requested, and pushes a new image
+// index referencing only the copied manifests. All other properties of the
+// source index, such as annotations, are preserved.
+func copyPlatforms(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, opts *copyOptions, extOpts oras.ExtendedCopyGraphOptions) (ocispec.Descriptor, error) {
+	if _, err := digest.Parse(opts.To.Reference); err == nil {
+		return ocispec.Descriptor{}, &oerrors.Error{
+			Err:            fmt.Errorf("cannot copy multiple platforms to a digest reference %q", opts.To.RawReference),
+			Recommendation: "A new image index is created for the selected platforms, please specify a tag for the destination instead.",
+		}
+	}
+	root, err := oras.Resolve(ctx, src, opts.From.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+	}
+	if root.MediaType != ocispec.MediaTypeImageIndex && root.MediaType != docker.MediaTypeManifestList {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to copy multiple platforms from %s: %s is neither an image index nor a manifest list", opts.From.Reference, root.MediaType)
+	}
+	indexBytes, manifests, err := filterIndexPlatforms(ctx, src, root, opts.Platforms.Platforms)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	for _, manifest := range manifests {
+		if opts.recursive {
+			err = recursiveCopy(ctx, src, dst, "", manifest, extOpts)
+		} else {
+			err = oras.CopyGraph(ctx, src, dst, manifest, extOpts.CopyGraphOptions)
+		}
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+
+	desc, err := pushManifest(ctx, copyHandler, dst, root.MediaType, indexBytes)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, tagCopiedRoot(ctx, dst, desc, opts.To.Reference)
+}
+
+// convertCopy copies the artifact resolved from the source reference while
+// converting docker manifests and manifest lists into OCI image manifests and
+// indexes. Blobs are copied as is since only their media types change.
+func convertCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, opts *copyOptions, rOpts oras.ResolveOptions, graphOpts oras.CopyGraphOptions) (ocispec.Descriptor, error) {
+	if _, err := digest.Parse(opts.To.Reference); err == nil {
+		return ocispec.Descriptor{}, &oerrors.Error{
+			Err:            fmt.Errorf("cannot copy converted manifests to a digest reference %q", opts.To.RawReference),
+			Recommendation: "Converted manifests have new digests, please specify a tag for the destination instead.",
+		}
+	}
+	root, err := oras.Resolve(ctx, src, opts.From.Reference, rOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+	}
+	converted, err := convertNode(ctx, copyHandler, src, dst, root, opts, graphOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return converted, tagCopiedRoot(ctx, dst, converted, opts.To.Reference)
+}
+
+// convertNode copies the graph rooted at node, converting docker manifests and
+// manifest lists on the way, and returns the descriptor of the copied node.
+func convertNode(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, node ocispec.Descriptor, opts *copyOptions, graphOpts oras.CopyGraphOptions) (ocispec.Descriptor, error) {
+	var converted []byte
+	switch node.MediaType {
+	case docker.MediaTypeManifest:
+		fetched, err := content.FetchAll(ctx, src, node)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		var manifest ocispec.Manifest
+		if err := json.Unmarshal(fetched, &manifest); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to parse %s: %w", node.Digest, err)
+		}
+		eg, egCtx := errgroup.WithContext(ctx)
+		eg.SetLimit(max(opts.concurrency, 1))
+		copied := make(map[digest.Digest]bool)
+		for _, blob := range append([]ocispec.Descriptor{manifest.Config}, manifest.Layers...) {
+			if copied[blob.Digest] {
+				continue
+			}
+			copied[blob.Digest] = true
+			eg.Go(func() error {
+				return oras.CopyGraph(egCtx, src, dst, blob, graphOpts)
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if converted, err = docker.ConvertManifest(fetched); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to convert %s: %w", node.Digest, err)
+		}
+	case docker.MediaTypeManifestList, ocispec.MediaTypeImageIndex:
+		fetched, err := content.FetchAll(ctx, src, node)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		changed := node.MediaType == docker.MediaTypeManifestList
+		converted, err = docker.ConvertIndex(fetched, func(child ocispec.Descriptor) (ocispec.Descriptor, error) {
+			convertedChild, err := convertNode(ctx, copyHandler, src, dst, child, opts, graphOpts)
+			if err != nil {
+				return ocispec.Descriptor{}, err
+			}
+			changed = changed || convertedChild.Digest != child.Digest
+			return convertedChild, nil
+		})
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to convert %s: %w", node.Digest, err)
+		}
+		if !changed {
+			// nothing to convert, copy the OCI index as is
+			return node, oras.CopyGraph(ctx, src, dst, node, graphOpts)
+		}
+	default:
+		return node, oras.CopyGraph(ctx, src, dst, node, graphOpts)
+	}
+
+	desc, err := pushManifest(ctx, copyHandler, dst, docker.ConvertMediaType(node.MediaType), converted)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if opts.onConverted != nil {
+		if err := opts.onConverted(node, desc); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	return desc, nil
+}
+
+// pushManifest pushes a manifest built during copy to dst with status
+// reported via copyHandler.
+func pushManifest(ctx context.Context, copyHandler status.CopyHandler, dst oras.GraphTarget, mediaType string, manifest []byte) (ocispec.Descriptor, error) {
+	desc := content.NewDescriptorFromBytes(mediaType, manifest)
+	exists, err := dst.Exists(ctx, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if exists {
+		return desc, copyHandler.OnCopySkipped(ctx, desc)
+	}
+	if err := copyHandler.PreCopy(ctx, desc); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if err := dst.Push(ctx, desc, bytes.NewReader(manifest)); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to push %s: %w", desc.Digest, err)
+	}
+	return desc, copyHandler.PostCopy(ctx, desc)
+}
+
+// tagCopiedRoot tags the root built during copy with ref, if ref is specified.
+func tagCopiedRoot(ctx context.Context, dst oras.Target, root ocispec.Descriptor, ref string) error {
+	if ref == "" {
+		return nil
+	}
+	if err := dst.Tag(ctx, root, ref); err != nil {
+		return fmt.Errorf("failed to tag %s with %s: %w", root.Digest, ref, err)
+	}
+	return nil
+}
+
+// filterReferrers filters referrers by the included and excluded artifact
+// types. Since it is applied to the predecessors found at each level of the
+// recursion, referrers of a skipped referrer are skipped as well.
+func (opts *copyOptions) filterReferrers(referrers []ocispec.Descriptor) []ocispec.Descriptor {
+	if len(opts.referrerTypes) == 0 && len(opts.excludeReferrerTypes) == 0 {
+		return referrers
+	}
+	return slices.DeleteFunc(referrers, func(referrer ocispec.Descriptor) bool {
+		if len(opts.referrerTypes) > 0 && !slices.Contains(opts.referrerTypes, referrer.ArtifactType) {
+			return true
+		}
+		return slices.Contains(opts.excludeReferrerTypes, referrer.ArtifactType)
+	})
+}
+
+// buildsNewRoot returns true if the copied root is built during copy and thus
+// differs from the source root.
+func (opts *copyOptions) buildsNewRoot() bool {
+	return opts.convert != "" || len(opts.Platforms.Platforms) > 1
+}
+
+// filterIndexPlatforms fetches the image index described by root and returns
+// its content with only the manifests matching any of the platforms, together
+// with the descriptors of the matched manifests.
+func filterIndexPlatforms(ctx context.Context, src content.ReadOnlyStorage, root ocispec.Descriptor, platforms []*ocispec.Platform) ([]byte, []ocispec.Descriptor, error) {
+	fetched, err := content.FetchAll(ctx, src, root)
+	if err != nil {
+		return nil, nil, err
+	}
+	// decode into raw messages so that unknown fields are kept as is
+	var index map[string]json.RawMessage
+	if err := json.Unmarshal(fetched, &index); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", root.Digest, err)
+	}
+	var rawManifests []json.RawMessage
+	if err := json.Unmarshal(index["manifests"], &rawManifests); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse manifests of %s: %w", root.Digest, err)
+	}
+
+	var kept []json.RawMessage
+	var manifests []ocispec.Descriptor
+	for _, raw := range rawManifests {
+		var manifest ocispec.Descriptor
+		if err := json.Unmarshal(raw, &manifest); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse manifests of %s: %w", root.Digest, err)
+		}
+		if slices.ContainsFunc(platforms, func(want *ocispec.Platform) bool {
+			return matchPlatform(manifest.Platform, want)
+		}) {
+			kept = append(kept, raw)
+			manifests = append(manifests, manifest)
+		}
+	}
+	if len(manifests) == 0 {
+		return nil, nil, fmt.Errorf("no manifest in %s matches the requested platforms", root.Digest)
+	}
+
+	if index["manifests"], err = json.Marshal(kept); err != nil {
+		return nil, nil, err
+	}
+	indexBytes, err := json.Marshal(index)
+	if err != nil {
+		return nil, nil, err
+	}
+	return indexBytes, manifests, nil
+}
+
+// matchPlatform returns true if got satisfies want. Variant and OS version
+// are only compared if they are specified in want.
+func matchPlatform(got, want *ocispec.Platform) bool {
+	if got == nil {
+		return false
+	}
+	return got.OS == want.OS &&
+		got.Architecture == want.Architecture &&
+		(want.Variant == "" || got.Variant == want.Variant) &&
+		(want.OSVersion == "" || got.OSVersion == want.OSVersion)
+}
+
 // recursiveCopy copies an artifact and its referrers from one target to another.
 // If the artifact is a manifest list or index, referrers of its manifests are copied as well.
 func recursiveCopy(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.Target, dstRef string, root ocispec.Descriptor, opts oras.ExtendedCopyGraphOptions) error {
@@ -21,22 +21,30 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras/cmd/oras/internal/display/status"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/docker"
 	"oras.land/oras/internal/testutils"
 )
 
@@ -452,3 +460,387 @@ func Test_getMountPoint(t *testing.T) {
 		})
 	}
 }
+
+func Test_doCopy_multiplePlatforms(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	push := func(mediaType string, v any) ocispec.Descriptor {
+		t.Helper()
+		content, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc, err := oras.PushBytes(ctx, src, mediaType, content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	config := push(ocispec.MediaTypeImageConfig, map[string]string{})
+	var manifests []ocispec.Descriptor
+	for _, platform := range []ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64", Variant: "v8"},
+		{OS: "windows", Architecture: "amd64"},
+	} {
+		desc := push(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+			Versioned:   specs.Versioned{SchemaVersion: 2},
+			MediaType:   ocispec.MediaTypeImageManifest,
+			Config:      config,
+			Layers:      []ocispec.Descriptor{},
+			Annotations: map[string]string{"platform": platform.OS + "/" + platform.Architecture},
+		})
+		desc.Platform = &platform
+		manifests = append(manifests, desc)
+	}
+	root := push(ocispec.MediaTypeImageIndex, ocispec.Index{
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   ocispec.MediaTypeImageIndex,
+		Manifests:   manifests,
+		Annotations: map[string]string{"org.opencontainers.image.version": "v1"},
+	})
+	if err := src.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts copyOptions
+	opts.From.Reference = "v1"
+	opts.To.Reference = "v1"
+	opts.Platforms.Platforms = []*ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+	}
+	dst := memory.New()
+	handler := status.NewTextCopyHandler(output.NewPrinter(io.Discard, io.Discard), dst)
+	desc, err := doCopy(ctx, handler, src, dst, &opts)
+	if err != nil {
+		t.Fatalf("doCopy() error = %v", err)
+	}
+	if desc.Digest == root.Digest {
+		t.Fatal("doCopy() should build a new index")
+	}
+
+	tagged, err := dst.Resolve(ctx, "v1")
+	if err != nil {
+		t.Fatalf("failed to resolve the copied index: %v", err)
+	}
+	fetched, err := content.FetchAll(ctx, dst, tagged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(fetched, &index); err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Manifests) != 2 || index.Manifests[0].Digest != manifests[0].Digest || index.Manifests[1].Digest != manifests[1].Digest {
+		t.Errorf("unexpected manifests in the copied index: %v", index.Manifests)
+	}
+	if index.Annotations["org.opencontainers.image.version"] != "v1" {
+		t.Errorf("annotations of the source index are not kept: %v", index.Annotations)
+	}
+	if exists, err := dst.Exists(ctx, manifests[2]); err != nil || exists {
+		t.Errorf("manifest of an unselected platform should not be copied, exists = %v, err = %v", exists, err)
+	}
+}
+
+func Test_doCopy_multiplePlatforms_noMatch(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	index := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`)
+	if _, err := oras.TagBytes(ctx, src, ocispec.MediaTypeImageIndex, index, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	var opts copyOptions
+	opts.From.Reference = "v1"
+	opts.Platforms.Platforms = []*ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+	}
+	dst := memory.New()
+	handler := status.NewTextCopyHandler(output.NewPrinter(io.Discard, io.Discard), dst)
+	if _, err := doCopy(ctx, handler, src, dst, &opts); err == nil {
+		t.Error("doCopy() error = nil, want error for no matching platform")
+	}
+}
+
+func Test_doCopy_convert(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	push := func(mediaType string, content string) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PushBytes(ctx, src, mediaType, []byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	config := push(docker.MediaTypeConfig, "{}")
+	layer := push(docker.MediaTypeLayerGzip, "layer")
+	manifest := push(docker.MediaTypeManifest, fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"config":{"mediaType":%q,"digest":%q,"size":%d},"layers":[{"mediaType":%q,"digest":%q,"size":%d}]}`,
+		docker.MediaTypeManifest, config.MediaType, config.Digest, config.Size, layer.MediaType, layer.Digest, layer.Size))
+	list := push(docker.MediaTypeManifestList, fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"manifests":[{"mediaType":%q,"digest":%q,"size":%d,"platform":{"architecture":"amd64","os":"linux"}}]}`,
+		docker.MediaTypeManifestList, manifest.MediaType, manifest.Digest, manifest.Size))
+	if err := src.Tag(ctx, list, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts copyOptions
+	opts.From.Reference = "v1"
+	opts.To.Reference = "v1"
+	opts.convert = convertFormatOCI
+	converted := make(map[digest.Digest]ocispec.Descriptor)
+	opts.onConverted = func(source, desc ocispec.Descriptor) error {
+		converted[source.Digest] = desc
+		return nil
+	}
+	dst := memory.New()
+	handler := status.NewTextCopyHandler(output.NewPrinter(io.Discard, io.Discard), dst)
+	root, err := doCopy(ctx, handler, src, dst, &opts)
+	if err != nil {
+		t.Fatalf("doCopy() error = %v", err)
+	}
+	if root.MediaType != ocispec.MediaTypeImageIndex {
+		t.Errorf("root media type = %q, want %q", root.MediaType, ocispec.MediaTypeImageIndex)
+	}
+	if len(converted) != 2 || converted[list.Digest].Digest != root.Digest {
+		t.Fatalf("unexpected conversions: %v", converted)
+	}
+
+	tagged, err := dst.Resolve(ctx, "v1")
+	if err != nil || tagged.Digest != root.Digest {
+		t.Fatalf("converted index is not tagged: %v, %v", tagged, err)
+	}
+	fetched, err := content.FetchAll(ctx, dst, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(fetched, &index); err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Manifests) != 1 || index.Manifests[0].MediaType != ocispec.MediaTypeImageManifest || index.Manifests[0].Digest != converted[manifest.Digest].Digest {
+		t.Fatalf("unexpected manifests in the converted index: %v", index.Manifests)
+	}
+	fetched, err = content.FetchAll(ctx, dst, index.Manifests[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got ocispec.Manifest
+	if err := json.Unmarshal(fetched, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Config.MediaType != ocispec.MediaTypeImageConfig || got.Layers[0].MediaType != ocispec.MediaTypeImageLayerGzip {
+		t.Errorf("media types are not converted: %+v", got)
+	}
+	for _, blob := range []ocispec.Descriptor{config, layer} {
+		if exists, err := dst.Exists(ctx, blob); err != nil || !exists {
+			t.Errorf("blob %s is not copied: %v", blob.Digest, err)
+		}
+	}
+}
+
+func Test_validateConvertFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    copyOptions
+		wantErr bool
+	}{
+		{name: "no conversion", opts: copyOptions{}},
+		{name: "oci", opts: copyOptions{convert: convertFormatOCI}},
+		{name: "unknown format", opts: copyOptions{convert: "docker"}, wantErr: true},
+		{name: "recursive", opts: copyOptions{convert: convertFormatOCI, recursive: true}, wantErr: true},
+		{name: "single platform", opts: copyOptions{convert: convertFormatOCI, Platforms: option.Platforms{Platforms: []*ocispec.Platform{{OS: "linux", Architecture: "amd64"}}}}},
+		{name: "multiple platforms", opts: copyOptions{convert: convertFormatOCI, Platforms: option.Platforms{Platforms: []*ocispec.Platform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64"}}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConvertFlag(&tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateConvertFlag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var oerr *oerrors.Error
+			if tt.wantErr && (!errors.As(err, &oerr) || oerr.Recommendation == "") {
+				t.Errorf("validateConvertFlag() error = %v, want an error with recommendation", err)
+			}
+		})
+	}
+}
+
+func Test_readCopyDestinations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "destinations.txt")
+	content := "# regional registries\nlocalhost:6000/app:v1\n\n  localhost:7000/app:v1,latest  \n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readCopyDestinations(path)
+	if err != nil {
+		t.Fatalf("readCopyDestinations() error = %v", err)
+	}
+	want := []string{"localhost:6000/app:v1", "localhost:7000/app:v1,latest"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readCopyDestinations() = %v, want %v", got, want)
+	}
+	if _, err := readCopyDestinations(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("readCopyDestinations() error = nil, want error for missing file")
+	}
+}
+
+func Test_doCopy_filterReferrers(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	subject, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.subject", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, subject, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	attach := func(artifactType string, subject ocispec.Descriptor) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{Subject: &subject})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	signature := attach("application/vnd.test.signature", subject)
+	debug := attach("application/vnd.test.debug", subject)
+	sbom := attach("application/vnd.test.sbom", subject)
+	sbomSignature := attach("application/vnd.test.signature", sbom)
+
+	tests := []struct {
+		name          string
+		include       []string
+		exclude       []string
+		wantCopied    []ocispec.Descriptor
+		wantNotCopied []ocispec.Descriptor
+	}{
+		{
+			name:          "include signatures",
+			include:       []string{"application/vnd.test.signature"},
+			wantCopied:    []ocispec.Descriptor{subject, signature},
+			wantNotCopied: []ocispec.Descriptor{debug, sbom, sbomSignature},
+		},
+		{
+			name:          "exclude debug symbols",
+			exclude:       []string{"application/vnd.test.debug"},
+			wantCopied:    []ocispec.Descriptor{subject, signature, sbom, sbomSignature},
+			wantNotCopied: []ocispec.Descriptor{debug},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts copyOptions
+			opts.recursive = true
+			opts.From.Reference = "v1"
+			opts.To.Reference = "v1"
+			opts.referrerTypes = tt.include
+			opts.excludeReferrerTypes = tt.exclude
+			dst := memory.New()
+			handler := status.NewTextCopyHandler(output.NewPrinter(io.Discard, io.Discard), dst)
+			if _, err := doCopy(ctx, handler, src, dst, &opts); err != nil {
+				t.Fatalf("doCopy() error = %v", err)
+			}
+			for _, desc := range tt.wantCopied {
+				if exists, err := dst.Exists(ctx, desc); err != nil || !exists {
+					t.Errorf("%s (%s) should be copied", desc.Digest, desc.ArtifactType)
+				}
+			}
+			for _, desc := range tt.wantNotCopied {
+				if exists, err := dst.Exists(ctx, desc); err != nil || exists {
+					t.Errorf("%s (%s) should not be copied", desc.Digest, desc.ArtifactType)
+				}
+			}
+		})
+	}
+}
+
+func Test_doCopy_checkpoint(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	subject, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.subject", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, subject, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	signature, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.signature", oras.PackManifestOptions{Subject: &subject})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sbom, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{Subject: &subject})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the signature is recorded as copied by an interrupted run
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	progress, err := openCheckpoint(path, "cp test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := progress.NodeDone(signature); err != nil {
+		t.Fatal(err)
+	}
+	if err := progress.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts copyOptions
+	opts.recursive = true
+	opts.From.Reference = "v1"
+	opts.To.Reference = "v1"
+	if opts.progress, err = openCheckpoint(path, "cp test"); err != nil {
+		t.Fatal(err)
+	}
+	defer opts.progress.Close()
+	dst := memory.New()
+	handler := status.NewTextCopyHandler(output.NewPrinter(io.Discard, io.Discard), dst)
+	if _, err := doCopy(ctx, handler, src, dst, &opts); err != nil {
+		t.Fatalf("doCopy() error = %v", err)
+	}
+	for _, desc := range []ocispec.Descriptor{subject, sbom} {
+		if exists, err := dst.Exists(ctx, desc); err != nil || !exists {
+			t.Errorf("%s (%s) should be copied", desc.Digest, desc.ArtifactType)
+		}
+		if !opts.progress.IsNodeDone(desc) {
+			t.Errorf("%s (%s) should be recorded in the checkpoint", desc.Digest, desc.ArtifactType)
+		}
+	}
+	if exists, err := dst.Exists(ctx, signature); err != nil || exists {
+		t.Errorf("%s (%s) should be skipped as recorded in the checkpoint", signature.Digest, signature.ArtifactType)
+	}
+
+	if _, err := openCheckpoint(path, "cp other"); err == nil {
+		t.Error("openCheckpoint() should fail for a different operation")
+	}
+}
+
+func Test_destinationStatusTarget(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("hello")
+	desc := content.NewDescriptorFromBytes("application/octet-stream", blob)
+	desc.Annotations = map[string]string{ocispec.AnnotationTitle: "hello.txt"}
+	out := &bytes.Buffer{}
+	dst := memory.New()
+	target := &destinationStatusTarget{
+		GraphTarget: dst,
+		handler:     status.NewTextDestinationCopyHandler(output.NewPrinter(out, os.Stderr), dst, "localhost:5000/dst:v1"),
+	}
+	if exists, err := target.Exists(ctx, desc); err != nil || exists {
+		t.Fatalf("Exists() = %v, %v, want false", exists, err)
+	}
+	if err := target.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if exists, err := target.Exists(ctx, desc); err != nil || !exists {
+		t.Fatalf("Exists() = %v, %v, want true", exists, err)
+	}
+	want := "[localhost:5000/dst:v1] Copying 2cf24dba5fb0 hello.txt\n" +
+		"[localhost:5000/dst:v1] Copied  2cf24dba5fb0 hello.txt\n" +
+		"[localhost:5000/dst:v1] Exists  2cf24dba5fb0 hello.txt\n"
+	if got := out.String(); got != want {
+		t.Errorf("status = %q, want %q", got, want)
+	}
+}
@@ -17,13 +17,18 @@ package root
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
@@ -31,6 +36,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
 )
 
 type discoverOptions struct {
@@ -40,8 +46,16 @@ type discoverOptions struct {
 	option.Format
 	option.Terminal
 
-	artifactType string
-	depth        int
+	artifactType  string
+	depth         int
+	up            bool
+	showChildren  bool
+	missingType   string
+	annotations   []string
+	createdAfter  string
+	createdBefore string
+	latest        int
+	filter        *referrerFilter
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -49,7 +63,7 @@ type discoverOptions struct {
 func discoverCmd() *cobra.Command {
 	var opts discoverOptions
 	cmd := &cobra.Command{
-		Use:   "discover [flags] <name>{:<tag>|@<digest>}",
+		Use:   "discover [flags] <name>[:<tag>|@<digest>]",
 		Short: "[Preview] Discover referrers of a manifest in a registry or an OCI image layout",
 		Long: `[Preview] Discover referrers of a manifest in a registry or an OCI image layout
 
@@ -58,6 +72,12 @@ func discoverCmd() *cobra.Command {
 Example - Discover referrers of manifest 'hello:v1' in registry 'localhost:5000', displayed in a tree view:
   oras discover localhost:5000/hello:v1
 
+Example - Discover referrers of all the tagged manifests in repository 'localhost:5000/hello':
+  oras discover localhost:5000/hello
+
+Example - [Experimental] List the tags in repository 'localhost:5000/hello' without a referrer of type 'application/vnd.cncf.notary.signature':
+  oras discover localhost:5000/hello --missing-type application/vnd.cncf.notary.signature
+
 Example - Discover referrers via referrers API:
   oras discover --distribution-spec v1.1-referrers-api localhost:5000/hello:v1
 
@@ -73,6 +93,24 @@ Example - [Experimental] Discover referrers and format output with Go template:
 Example - [Experimental] Discover only direct referrers, displayed in json view:
   oras discover localhost:5000/hello:v1 --format json --depth 1
 
+Example - Discover the subjects of referrer 'hello@sha256:xxx' up to the root artifact and the tags pointing at the root:
+  oras discover --up localhost:5000/hello@sha256:xxx
+
+Example - [Experimental] Discover referrers and print a Graphviz DOT graph:
+  oras discover localhost:5000/hello:v1 --format dot
+
+Example - [Experimental] Discover referrers of an index and its child manifests, printed as a Mermaid flowchart:
+  oras discover localhost:5000/hello:v1 --format mermaid --show-manifests
+
+Example - [Experimental] Discover referrers with annotation 'org.example.status' matching 'approved|released':
+  oras discover localhost:5000/hello:v1 --annotation "org.example.status=approved|released"
+
+Example - [Experimental] Discover referrers created within the last 7 days:
+  oras discover localhost:5000/hello:v1 --created-after 7d
+
+Example - [Experimental] Discover the latest referrer of type 'application/vnd.example.vulnerability-report':
+  oras discover localhost:5000/hello:v1 --artifact-type application/vnd.example.vulnerability-report --latest 1
+
 Example - Discover referrers with type 'test-artifact' of manifest 'hello:v1' in registry 'localhost:5000':
   oras discover --artifact-type test-artifact localhost:5000/hello:v1
 
@@ -87,6 +125,18 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "format", "output"); err != nil {
 				return err
 			}
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "up", "depth"); err != nil {
+				return err
+			}
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "up", "artifact-type"); err != nil {
+				return err
+			}
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "up", "missing-type"); err != nil {
+				return err
+			}
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "artifact-type", "missing-type"); err != nil {
+				return err
+			}
 			if cmd.Flags().Changed("depth") && opts.depth < 1 {
 				return errors.New("depth value should be at least 1")
 			}
@@ -98,6 +148,44 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 			if err := option.Parse(cmd, &opts); err != nil {
 				return err
 			}
+			for _, name := range []string{"annotation", "created-after", "created-before", "latest"} {
+				if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "up", name); err != nil {
+					return err
+				}
+			}
+			var err error
+			if opts.filter, err = newReferrerFilter(opts.annotations, opts.createdAfter, opts.createdBefore, opts.latest, time.Now()); err != nil {
+				return err
+			}
+			if opts.missingType != "" && opts.Reference != "" {
+				return &oerrors.Error{
+					Err:            errors.New("--missing-type cannot be used with a tag or a digest"),
+					Recommendation: fmt.Sprintf("remove the tag or the digest from %q to check all the tags in the repository", opts.RawReference),
+				}
+			}
+			switch opts.Format.Type {
+			case option.FormatTypeTable.Name, option.FormatTypeDOT.Name, option.FormatTypeMermaid.Name:
+				// only the referrers of a single manifest can be rendered
+				if opts.up {
+					return &oerrors.Error{
+						Err:            fmt.Errorf("--up cannot be used with %s format", opts.Format.Type),
+						Recommendation: "use tree, json or go-template format to discover the subjects",
+					}
+				}
+				if opts.Reference == "" {
+					return &oerrors.Error{
+						Err:            fmt.Errorf("%s format cannot be used to discover the referrers of all tags", opts.Format.Type),
+						Recommendation: fmt.Sprintf("specify a tag or a digest in %q, or use tree, json or go-template format", opts.RawReference),
+					}
+				}
+			}
+			if opts.showChildren {
+				switch opts.Format.Type {
+				case option.FormatTypeDOT.Name, option.FormatTypeMermaid.Name:
+				default:
+					return errors.New("--show-manifests can only be used with dot or mermaid format")
+				}
+			}
 			if cmd.Flags().Changed("output") {
 				switch opts.Format.Type {
 				case option.FormatTypeTree.Name, option.FormatTypeJSON.Name, option.FormatTypeTable.Name:
@@ -118,12 +206,21 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 	cmd.Flags().StringVarP(&opts.FormatFlag, "output", "o", "tree", "[Deprecated] format in which to display referrers (table, json, or tree).")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "display full metadata of referrers")
 	cmd.Flags().IntVarP(&opts.depth, "depth", "", 0, "[Experimental] level of referrers to display, if unused shows referrers of all levels")
+	cmd.Flags().BoolVarP(&opts.showChildren, "show-manifests", "", false, "[Experimental] show the child manifests of an index and their referrers in dot or mermaid format")
+	cmd.Flags().StringVarP(&opts.missingType, "missing-type", "", "", "[Experimental] list only the tags without a referrer of the artifact type, used when no tag or digest is specified")
+	cmd.Flags().StringArrayVarP(&opts.annotations, "annotation", "", nil, "[Experimental] discover only referrers with the annotation in the form of key=value, where the value is a regular expression matching the whole annotation value")
+	cmd.Flags().StringVarP(&opts.createdAfter, "created-after", "", "", "[Experimental] discover only referrers created after the time in RFC 3339 format or the duration before now, such as 72h or 30d, by the org.opencontainers.image.created annotation")
+	cmd.Flags().StringVarP(&opts.createdBefore, "created-before", "", "", "[Experimental] discover only referrers created before the time in RFC 3339 format or the duration before now, such as 72h or 30d, by the org.opencontainers.image.created annotation")
+	cmd.Flags().IntVarP(&opts.latest, "latest", "", 0, "[Experimental] discover only the latest N referrers of each artifact type by the org.opencontainers.image.created annotation")
+	cmd.Flags().BoolVarP(&opts.up, "up", "", false, "[Experimental] follow the subjects of the artifact up to the root and show the tags pointing at the root")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.SetTypes(
 		option.FormatTypeTree,
 		option.FormatTypeTable,
 		option.FormatTypeJSON.WithUsage("Get referrers and output in JSON format"),
 		option.FormatTypeGoTemplate.WithUsage("Print referrers using the given Go template"),
+		option.FormatTypeDOT.WithUsage("Print the referrer graph in Graphviz DOT format"),
+		option.FormatTypeMermaid.WithUsage("Print the referrer graph as a Mermaid flowchart"),
 	)
 	opts.EnableDistributionSpecFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
@@ -133,37 +230,59 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 
 func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
-	repo, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	repo, closer, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	resolveOpts := oras.DefaultResolveOptions
+	resolveOpts.TargetPlatform = opts.Platform.Platform
+	if opts.Reference == "" && !opts.up {
+		return runDiscoverRepository(ctx, repo, resolveOpts, opts)
+	}
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
 
 	// discover artifacts
-	resolveOpts := oras.DefaultResolveOptions
-	resolveOpts.TargetPlatform = opts.Platform.Platform
 	desc, err := oras.Resolve(ctx, repo, opts.Reference, resolveOpts)
 	if err != nil {
 		return err
 	}
 
-	handler, err := display.NewDiscoverHandler(opts.Printer, opts.Format, opts.Path, opts.RawReference, desc, opts.verbose, opts.TTY)
+	if opts.up {
+		return runDiscoverUp(ctx, repo, desc, opts)
+	}
+
+	var children []ocispec.Descriptor
+	if opts.showChildren && descriptor.IsIndex(desc) {
+		if children, err = fetchIndexManifests(ctx, repo, desc); err != nil {
+			return err
+		}
+	}
+	handler, err := display.NewDiscoverHandler(opts.Printer, opts.Format, opts.Path, opts.RawReference, desc, children, opts.verbose, opts.TTY)
 	if err != nil {
 		return err
 	}
-	if err := fetchAllReferrers(ctx, repo, desc, opts.artifactType, handler, opts.depth); err != nil {
+	if err := fetchAllReferrers(ctx, repo, desc, opts.artifactType, opts.filter, handler, opts.depth); err != nil {
 		return err
 	}
+	for _, child := range children {
+		if err := fetchAllReferrers(ctx, repo, child, opts.artifactType, opts.filter, handler, opts.depth); err != nil {
+			return err
+		}
+	}
 	return handler.Render()
 }
 
-func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, artifactType string, handler metadata.DiscoverHandler, depth int) error {
+func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, artifactType string, filter *referrerFilter, handler metadata.DiscoverHandler, depth int) error {
 	results, err := registry.Referrers(ctx, repo, desc, artifactType)
 	if err != nil {
 		return err
 	}
+	results = filter.apply(results)
 
 	var nextDepth int
 	if depth > 0 {
@@ -180,9 +299,246 @@ func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc
 			Digest:    r.Digest,
 			Size:      r.Size,
 			MediaType: r.MediaType,
-		}, artifactType, handler, nextDepth); err != nil {
+		}, artifactType, filter, handler, nextDepth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// taggedManifest is a manifest with the tags resolving to it.
+type taggedManifest struct {
+	desc ocispec.Descriptor
+	tags []string
+}
+
+// runDiscoverRepository discovers referrers of all the tagged manifests in the
+// repository, where the tags resolving to the same manifest are grouped.
+func runDiscoverRepository(ctx context.Context, repo oras.ReadOnlyGraphTarget, resolveOpts oras.ResolveOptions, opts *discoverOptions) error {
+	lister, ok := repo.(registry.TagLister)
+	if !ok {
+		return fmt.Errorf("unable to list tags of %s", opts.RawReference)
+	}
+	tags, err := registry.Tags(ctx, lister)
+	if err != nil {
+		return err
+	}
+	manifests, err := resolveTaggedManifests(ctx, repo, tags, resolveOpts)
+	if err != nil {
+		return err
+	}
+
+	handler, err := display.NewDiscoverRepositoryHandler(opts.Printer, opts.Format, opts.Path, opts.verbose, opts.TTY)
+	if err != nil {
+		return err
+	}
+	for _, m := range manifests {
+		if opts.missingType != "" {
+			referrers, err := registry.Referrers(ctx, repo, m.desc, opts.missingType)
+			if err != nil {
+				return err
+			}
+			if len(opts.filter.apply(referrers)) == 0 {
+				if err := handler.OnTagsResolved(m.tags, m.desc); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := handler.OnTagsResolved(m.tags, m.desc); err != nil {
+			return err
+		}
+		if err := fetchAllReferrers(ctx, repo, m.desc, opts.artifactType, opts.filter, handler, opts.depth); err != nil {
+			return err
+		}
+	}
+	return handler.Render()
+}
+
+// resolveTaggedManifests resolves the tags and groups the tags resolving to
+// the same manifest, in the order of the tags. Tags of the referrers tag
+// schema are skipped, as well as tags not found or not matching the platform.
+func resolveTaggedManifests(ctx context.Context, repo oras.ReadOnlyTarget, tags []string, resolveOpts oras.ResolveOptions) ([]*taggedManifest, error) {
+	var manifests []*taggedManifest
+	byDigest := make(map[digest.Digest]*taggedManifest)
+	for _, tag := range tags {
+		if isReferrersTag(tag) {
+			continue
+		}
+		desc, err := oras.Resolve(ctx, repo, tag, resolveOpts)
+		if err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if m, ok := byDigest[desc.Digest]; ok {
+			m.tags = append(m.tags, tag)
+			continue
+		}
+		m := &taggedManifest{
+			desc: desc,
+			tags: []string{tag},
+		}
+		byDigest[desc.Digest] = m
+		manifests = append(manifests, m)
+	}
+	return manifests, nil
+}
+
+// subjectManifest contains the fields of a manifest needed to follow its
+// subject.
+type subjectManifest struct {
+	ArtifactType string              `json:"artifactType,omitempty"`
+	Config       *ocispec.Descriptor `json:"config,omitempty"`
+	Subject      *ocispec.Descriptor `json:"subject,omitempty"`
+}
+
+// artifactType returns the artifact type of the manifest, falling back to the
+// config media type of a manifest other than an image.
+func (m subjectManifest) artifactType() string {
+	if m.ArtifactType != "" {
+		return m.ArtifactType
+	}
+	if m.Config != nil && m.Config.MediaType != ocispec.MediaTypeImageConfig {
+		return m.Config.MediaType
+	}
+	return ""
+}
+
+// fetchSubjectManifest fetches the manifest described by desc.
+func fetchSubjectManifest(ctx context.Context, repo oras.ReadOnlyTarget, desc ocispec.Descriptor) (subjectManifest, error) {
+	var manifest subjectManifest
+	manifestJSON, err := content.FetchAll(ctx, repo, desc)
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+	}
+	return manifest, nil
+}
+
+func runDiscoverUp(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc ocispec.Descriptor, opts *discoverOptions) error {
+	manifest, err := fetchSubjectManifest(ctx, repo, desc)
+	if err != nil {
+		return err
+	}
+	desc.ArtifactType = manifest.artifactType()
+	handler, err := display.NewDiscoverUpHandler(opts.Printer, opts.Format, opts.Path, desc, opts.TTY)
+	if err != nil {
+		return err
+	}
+	root, err := fetchSubjects(ctx, repo, desc, manifest, handler)
+	if err != nil {
+		return err
+	}
+	if err := fetchRootTags(ctx, repo, root, handler); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// fetchSubjects follows the subjects from the referrer desc with the manifest
+// up to the root, which is an artifact without subject, and returns the root.
+func fetchSubjects(ctx context.Context, repo oras.ReadOnlyTarget, desc ocispec.Descriptor, manifest subjectManifest, handler metadata.DiscoverUpHandler) (ocispec.Descriptor, error) {
+	visited := map[digest.Digest]bool{
+		desc.Digest: true,
+	}
+	for manifest.Subject != nil {
+		subject := *manifest.Subject
+		if visited[subject.Digest] {
+			return ocispec.Descriptor{}, fmt.Errorf("subject %s of %s forms a cycle", subject.Digest, desc.Digest)
+		}
+		visited[subject.Digest] = true
+
+		next, err := fetchSubjectManifest(ctx, repo, subject)
+		if err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				return ocispec.Descriptor{}, fmt.Errorf("subject %s of %s is not found: %w", subject.Digest, desc.Digest, err)
+			}
+			return ocispec.Descriptor{}, err
+		}
+		subject.ArtifactType = next.artifactType()
+		if err := handler.OnSubjectFound(desc, subject); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		desc, manifest = subject, next
+	}
+	return desc, nil
+}
+
+// fetchRootTags reports the tags pointing at the root, either directly or via
+// an index containing the root. Nothing is reported if the tags cannot be
+// listed from the target.
+func fetchRootTags(ctx context.Context, repo oras.ReadOnlyTarget, root ocispec.Descriptor, handler metadata.DiscoverUpHandler) error {
+	lister, ok := repo.(registry.TagLister)
+	if !ok {
+		return nil
+	}
+	tags, err := registry.Tags(ctx, lister)
+	if err != nil {
+		return err
+	}
+
+	// indexes are cached as multiple tags may point at the same index
+	containsRoot := make(map[digest.Digest]bool)
+	for _, tag := range tags {
+		desc, err := repo.Resolve(ctx, tag)
+		if err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				// the tag may be deleted after being listed
+				continue
+			}
+			return err
+		}
+		if desc.Digest != root.Digest {
+			if !descriptor.IsIndex(desc) {
+				continue
+			}
+			contains, ok := containsRoot[desc.Digest]
+			if !ok {
+				if contains, err = indexContains(ctx, repo, desc, root); err != nil {
+					return err
+				}
+				containsRoot[desc.Digest] = contains
+			}
+			if !contains {
+				continue
+			}
+		}
+		if err := handler.OnRootTagged(tag, desc); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// indexContains returns true if the index lists the manifest target.
+func indexContains(ctx context.Context, repo oras.ReadOnlyTarget, index ocispec.Descriptor, target ocispec.Descriptor) (bool, error) {
+	manifests, err := fetchIndexManifests(ctx, repo, index)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range manifests {
+		if m.Digest == target.Digest {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fetchIndexManifests fetches the manifests listed by the index.
+func fetchIndexManifests(ctx context.Context, repo oras.ReadOnlyTarget, index ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	indexJSON, err := content.FetchAll(ctx, repo, index)
+	if err != nil {
+		return nil, err
+	}
+	var manifests struct {
+		Manifests []ocispec.Descriptor `json:"manifests"`
+	}
+	if err := json.Unmarshal(indexJSON, &manifests); err != nil {
+		return nil, fmt.Errorf("failed to parse index %s: %w", index.Digest, err)
+	}
+	return manifests.Manifests, nil
+}
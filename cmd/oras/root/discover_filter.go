@@ -0,0 +1,151 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// referrerFilter selects the referrers to be discovered.
+type referrerFilter struct {
+	// annotations are the annotations required on the referrers, where the
+	// values are regular expressions matching the whole annotation values.
+	annotations map[string]*regexp.Regexp
+	// createdAfter excludes the referrers created before it if not zero.
+	createdAfter time.Time
+	// createdBefore excludes the referrers created after it if not zero.
+	createdBefore time.Time
+	// latest keeps only the latest referrers of each artifact type if
+	// positive.
+	latest int
+}
+
+// newReferrerFilter creates a referrerFilter from the flag values. It returns
+// nil if no filter is specified.
+func newReferrerFilter(annotations []string, createdAfter, createdBefore string, latest int, now time.Time) (*referrerFilter, error) {
+	if len(annotations) == 0 && createdAfter == "" && createdBefore == "" && latest == 0 {
+		return nil, nil
+	}
+	if latest < 0 {
+		return nil, fmt.Errorf("invalid number of latest referrers %d: must not be negative", latest)
+	}
+	filter := referrerFilter{
+		annotations: make(map[string]*regexp.Regexp),
+		latest:      latest,
+	}
+	for _, annotation := range annotations {
+		key, value, ok := strings.Cut(annotation, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid annotation filter %q: expected key=value", annotation)
+		}
+		regex, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid annotation filter %q: %w", annotation, err)
+		}
+		filter.annotations[key] = regex
+	}
+	var err error
+	if createdAfter != "" {
+		if filter.createdAfter, err = parseSince(createdAfter, now); err != nil {
+			return nil, err
+		}
+	}
+	if createdBefore != "" {
+		if filter.createdBefore, err = parseSince(createdBefore, now); err != nil {
+			return nil, err
+		}
+	}
+	return &filter, nil
+}
+
+// apply returns the referrers selected by the filter, keeping the order of the
+// referrers except that the latest referrers are sorted from the newest.
+// Referrers without a valid creation time are excluded by the time window,
+// and are considered the oldest when selecting the latest referrers.
+func (f *referrerFilter) apply(referrers []ocispec.Descriptor) []ocispec.Descriptor {
+	if f == nil {
+		return referrers
+	}
+	var selected []ocispec.Descriptor
+	for _, r := range referrers {
+		if f.match(r) {
+			selected = append(selected, r)
+		}
+	}
+	if f.latest == 0 {
+		return selected
+	}
+
+	slices.SortStableFunc(selected, func(a, b ocispec.Descriptor) int {
+		createdA, _ := referrerCreated(a)
+		createdB, _ := referrerCreated(b)
+		return createdB.Compare(createdA)
+	})
+	count := make(map[string]int)
+	latest := selected[:0]
+	for _, r := range selected {
+		if count[r.ArtifactType] < f.latest {
+			count[r.ArtifactType]++
+			latest = append(latest, r)
+		}
+	}
+	return latest
+}
+
+// match returns true if the referrer matches the annotations and the time
+// window.
+func (f *referrerFilter) match(referrer ocispec.Descriptor) bool {
+	for key, regex := range f.annotations {
+		value, ok := referrer.Annotations[key]
+		if !ok || !regex.MatchString(value) {
+			return false
+		}
+	}
+	if f.createdAfter.IsZero() && f.createdBefore.IsZero() {
+		return true
+	}
+	created, ok := referrerCreated(referrer)
+	if !ok {
+		return false
+	}
+	if !f.createdAfter.IsZero() && created.Before(f.createdAfter) {
+		return false
+	}
+	if !f.createdBefore.IsZero() && created.After(f.createdBefore) {
+		return false
+	}
+	return true
+}
+
+// referrerCreated returns the creation time of the referrer in its
+// annotations.
+func referrerCreated(referrer ocispec.Descriptor) (time.Time, bool) {
+	value, ok := referrer.Annotations[ocispec.AnnotationCreated]
+	if !ok {
+		return time.Time{}, false
+	}
+	created, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return created, true
+}
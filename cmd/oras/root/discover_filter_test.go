@@ -0,0 +1,144 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func Test_newReferrerFilter(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		annotations []string
+		after       string
+		before      string
+		latest      int
+		wantNil     bool
+		wantErr     bool
+	}{
+		{name: "no filter", wantNil: true},
+		{name: "annotation", annotations: []string{"org.example.status=approved|released"}},
+		{name: "annotation with empty value", annotations: []string{"org.example.status="}},
+		{name: "annotation without value", annotations: []string{"org.example.status"}, wantErr: true},
+		{name: "annotation without key", annotations: []string{"=approved"}, wantErr: true},
+		{name: "invalid annotation regex", annotations: []string{"org.example.status=["}, wantErr: true},
+		{name: "created after", after: "7d"},
+		{name: "created before", before: "2025-01-01T00:00:00Z"},
+		{name: "invalid created after", after: "last week", wantErr: true},
+		{name: "latest", latest: 1},
+		{name: "negative latest", latest: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newReferrerFilter(tt.annotations, tt.after, tt.before, tt.latest, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newReferrerFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got == nil) != tt.wantNil {
+				t.Fatalf("newReferrerFilter() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+func Test_referrerFilter_apply(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	referrer := func(name, artifactType, created, status string) ocispec.Descriptor {
+		annotations := map[string]string{}
+		if created != "" {
+			annotations[ocispec.AnnotationCreated] = created
+		}
+		if status != "" {
+			annotations["org.example.status"] = status
+		}
+		return ocispec.Descriptor{
+			MediaType:    ocispec.MediaTypeImageManifest,
+			Digest:       digest.FromString(name),
+			ArtifactType: artifactType,
+			Annotations:  annotations,
+		}
+	}
+	oldReport := referrer("old report", "report", "2025-06-01T00:00:00Z", "approved")
+	newReport := referrer("new report", "report", "2025-06-29T00:00:00Z", "draft")
+	unknownReport := referrer("unknown report", "report", "", "approved")
+	signature := referrer("signature", "signature", "2025-06-28T00:00:00Z", "released")
+	referrers := []ocispec.Descriptor{oldReport, unknownReport, signature, newReport}
+
+	tests := []struct {
+		name        string
+		annotations []string
+		after       string
+		before      string
+		latest      int
+		want        []ocispec.Descriptor
+	}{
+		{
+			name: "no filter",
+			want: referrers,
+		},
+		{
+			name:        "annotation",
+			annotations: []string{"org.example.status=approved|released"},
+			want:        []ocispec.Descriptor{oldReport, unknownReport, signature},
+		},
+		{
+			name:        "annotation matching whole value",
+			annotations: []string{"org.example.status=approve"},
+		},
+		{
+			name:  "created after",
+			after: "7d",
+			want:  []ocispec.Descriptor{signature, newReport},
+		},
+		{
+			name:   "created before",
+			before: "2025-06-28T12:00:00Z",
+			want:   []ocispec.Descriptor{oldReport, signature},
+		},
+		{
+			name:   "latest",
+			latest: 1,
+			want:   []ocispec.Descriptor{newReport, signature},
+		},
+		{
+			name:        "latest matching annotation",
+			annotations: []string{"org.example.status=approved"},
+			latest:      1,
+			want:        []ocispec.Descriptor{oldReport},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newReferrerFilter(tt.annotations, tt.after, tt.before, tt.latest, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := filter.apply(append([]ocispec.Descriptor(nil), referrers...))
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	jsonmetadata "oras.land/oras/cmd/oras/internal/display/metadata/json"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func Test_fetchSubjects(t *testing.T) {
+	ctx := context.Background()
+	repo, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pack := func(artifactType string, subject *ocispec.Descriptor) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{Subject: subject})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	image := pack("application/vnd.test.image", nil)
+	other := pack("application/vnd.test.other", nil)
+	sbom := pack("application/vnd.test.sbom", &image)
+	scan := pack("application/vnd.test.scan", &sbom)
+	indexJSON, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{image},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := oras.PushBytes(ctx, repo, ocispec.MediaTypeImageIndex, indexJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for tag, desc := range map[string]ocispec.Descriptor{
+		"v1":     image,
+		"latest": index,
+		"other":  other,
+		"sbom":   sbom,
+	} {
+		if err := repo.Tag(ctx, desc, tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	handler := jsonmetadata.NewDiscoverUpHandler(&buf, scan, "test")
+	manifest, err := fetchSubjectManifest(ctx, repo, scan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := fetchSubjects(ctx, repo, scan, manifest, handler)
+	if err != nil {
+		t.Fatalf("fetchSubjects() error = %v", err)
+	}
+	if root.Digest != image.Digest {
+		t.Fatalf("fetchSubjects() = %s, want %s", root.Digest, image.Digest)
+	}
+	if err := fetchRootTags(ctx, repo, root, handler); err != nil {
+		t.Fatalf("fetchRootTags() error = %v", err)
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Chain []ocispec.Descriptor `json:"chain"`
+		Root  ocispec.Descriptor   `json:"root"`
+		Tags  []struct {
+			Tag    string        `json:"tag"`
+			Digest digest.Digest `json:"digest"`
+		} `json:"tags"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	var chain []digest.Digest
+	for _, desc := range got.Chain {
+		chain = append(chain, desc.Digest)
+	}
+	if want := []digest.Digest{scan.Digest, sbom.Digest, image.Digest}; !slices.Equal(chain, want) {
+		t.Errorf("chain = %v, want %v", chain, want)
+	}
+	if got.Chain[1].ArtifactType != "application/vnd.test.sbom" {
+		t.Errorf("artifact type of the subject = %q, want %q", got.Chain[1].ArtifactType, "application/vnd.test.sbom")
+	}
+	if got.Root.Digest != image.Digest {
+		t.Errorf("root = %s, want %s", got.Root.Digest, image.Digest)
+	}
+	tags := make(map[string]digest.Digest)
+	for _, tag := range got.Tags {
+		tags[tag.Tag] = tag.Digest
+	}
+	if want := map[string]digest.Digest{"v1": image.Digest, "latest": index.Digest}; !maps.Equal(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+}
+
+func Test_fetchSubjects_missingSubject(t *testing.T) {
+	ctx := context.Background()
+	repo, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("missing"),
+		Size:      7,
+	}
+	referrer, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{Subject: &missing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := fetchSubjectManifest(ctx, repo, referrer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := jsonmetadata.NewDiscoverUpHandler(&bytes.Buffer{}, referrer, "test")
+	if _, err := fetchSubjects(ctx, repo, referrer, manifest, handler); err == nil {
+		t.Error("fetchSubjects() should fail when the subject is not found")
+	}
+}
+
+func Test_runDiscoverRepository(t *testing.T) {
+	ctx := context.Background()
+	repo, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pack := func(artifactType string, subject *ocispec.Descriptor, tags ...string) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{Subject: subject})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tag := range tags {
+			if err := repo.Tag(ctx, desc, tag); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return desc
+	}
+	signed := pack("application/vnd.test.image", nil, "v1", "latest")
+	unsigned := pack("application/vnd.test.other", nil, "v2")
+	signature := pack("application/vnd.test.signature", &signed)
+	pack("application/vnd.test.sbom", &unsigned, "sha256-"+unsigned.Digest.Encoded())
+
+	discover := func(missingType string) []struct {
+		Digest    digest.Digest        `json:"digest"`
+		Tags      []string             `json:"tags"`
+		Referrers []ocispec.Descriptor `json:"referrers"`
+	} {
+		t.Helper()
+		var buf bytes.Buffer
+		opts := &discoverOptions{missingType: missingType}
+		opts.Printer = output.NewPrinter(&buf, io.Discard)
+		opts.Format.Type = option.FormatTypeJSON.Name
+		opts.Path = "test"
+		if err := runDiscoverRepository(ctx, repo, oras.DefaultResolveOptions, opts); err != nil {
+			t.Fatalf("runDiscoverRepository() error = %v", err)
+		}
+		var got struct {
+			Manifests []struct {
+				Digest    digest.Digest        `json:"digest"`
+				Tags      []string             `json:"tags"`
+				Referrers []ocispec.Descriptor `json:"referrers"`
+			} `json:"manifests"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		return got.Manifests
+	}
+
+	got := discover("")
+	if len(got) != 2 {
+		t.Fatalf("got %d manifests, want 2: %v", len(got), got)
+	}
+	for _, m := range got {
+		switch m.Digest {
+		case signed.Digest:
+			if want := []string{"latest", "v1"}; !slices.Equal(m.Tags, want) {
+				t.Errorf("tags of %s = %v, want %v", m.Digest, m.Tags, want)
+			}
+			if len(m.Referrers) != 1 || m.Referrers[0].Digest != signature.Digest {
+				t.Errorf("referrers of %s = %v, want %s", m.Digest, m.Referrers, signature.Digest)
+			}
+		case unsigned.Digest:
+			if want := []string{"v2"}; !slices.Equal(m.Tags, want) {
+				t.Errorf("tags of %s = %v, want %v", m.Digest, m.Tags, want)
+			}
+		default:
+			t.Errorf("unexpected manifest %s", m.Digest)
+		}
+	}
+
+	got = discover("application/vnd.test.signature")
+	if len(got) != 1 || got[0].Digest != unsigned.Digest || len(got[0].Referrers) != 0 {
+		t.Errorf("manifests missing signatures = %v, want only %s without referrers", got, unsigned.Digest)
+	}
+}
+
+func Test_discoverCmd_unsupportedFormat(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "dot without tag",
+			args: []string{"--oci-layout", "--format", "dot", dir},
+			want: "dot format cannot be used to discover the referrers of all tags",
+		},
+		{
+			name: "mermaid without tag",
+			args: []string{"--oci-layout", "--format", "mermaid", dir},
+			want: "mermaid format cannot be used to discover the referrers of all tags",
+		},
+		{
+			name: "dot with up",
+			args: []string{"--oci-layout", "--format", "dot", "--up", dir + ":v1"},
+			want: "--up cannot be used with dot format",
+		},
+		{
+			name: "table with up",
+			args: []string{"--oci-layout", "--format", "table", "--up", dir + ":v1"},
+			want: "--up cannot be used with table format",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := discoverCmd()
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tt.args)
+			if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Execute() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
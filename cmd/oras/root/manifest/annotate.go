@@ -0,0 +1,194 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/registryutil"
+)
+
+type annotateOptions struct {
+	option.Common
+	option.Target
+
+	setArguments    []string
+	removeArguments []string
+	rebaseReferrers bool
+
+	set map[string]string
+}
+
+func annotateCmd() *cobra.Command {
+	var opts annotateOptions
+	cmd := &cobra.Command{
+		Use:   "annotate [flags] <name>{:<tag>|@<digest>} {--set <key>=<value>|--remove <key>} [...]",
+		Short: "[Experimental] Update the annotations of a manifest or an index",
+		Long: `[Experimental] Update the annotations of a manifest or an index
+
+The manifest is rewritten with the updated annotations and pushed to the repository. Since updating the annotations
+changes the digest of the manifest, the old and new digests are printed. If the manifest is referenced by a tag, the tag
+is moved to the new manifest. The old manifest is kept in the repository.
+
+Example - Fix the value of an annotation of the manifest tagged 'v1':
+  oras manifest annotate localhost:5000/hello:v1 --set org.opencontainers.image.source=https://github.com/oras-project/oras
+
+Example - Set an annotation and remove another one:
+  oras manifest annotate localhost:5000/hello:v1 --set com.example.key=value --remove com.example.typo
+
+Example - Update the annotations and carry the referrers of the old manifest over to the new one:
+  oras manifest annotate --rebase-referrers localhost:5000/hello:v1 --set com.example.key=value
+
+Example - Update the annotations of a manifest in an OCI image layout folder 'layout-dir':
+  oras manifest annotate --oci-layout layout-dir:v1 --set com.example.key=value
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the manifest to annotate"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if err := opts.parseAnnotations(); err != nil {
+				return err
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return annotateManifest(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.setArguments, "set", "", nil, "annotations to set in the format of `key=value`")
+	cmd.Flags().StringArrayVarP(&opts.removeArguments, "remove", "", nil, "keys of the annotations to remove")
+	cmd.Flags().BoolVarP(&opts.rebaseReferrers, "rebase-referrers", "", false, "rebase the referrers of the old manifest onto the new manifest")
+	opts.EnableDistributionSpecFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+// parseAnnotations parses the annotations to set and checks the annotations to
+// remove.
+func (opts *annotateOptions) parseAnnotations() error {
+	if len(opts.setArguments) == 0 && len(opts.removeArguments) == 0 {
+		return &oerrors.Error{
+			Err:            errors.New("no annotation to update"),
+			Recommendation: `Please specify the annotations to update via the flag "--set" or "--remove"`,
+		}
+	}
+	opts.set = make(map[string]string)
+	for _, arg := range opts.setArguments {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("invalid annotation %q", arg),
+				Recommendation: `Please use the correct format in the flag: --set "key=value"`,
+			}
+		}
+		if _, ok := opts.set[key]; ok {
+			return fmt.Errorf("duplicate annotation key: %s", key)
+		}
+		opts.set[key] = value
+	}
+	for _, key := range opts.removeArguments {
+		if _, ok := opts.set[key]; ok {
+			return fmt.Errorf("annotation %s cannot be both set and removed", key)
+		}
+	}
+	return nil
+}
+
+func annotateManifest(cmd *cobra.Command, opts *annotateOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	target, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	ctx = registryutil.WithScopeHint(ctx, target, auth.ActionPull, auth.ActionPush)
+
+	desc, manifestBytes, err := oras.FetchBytes(ctx, target, opts.Reference, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", opts.RawReference, err)
+	}
+	switch desc.MediaType {
+	case ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex:
+	default:
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%s of media type %s does not support annotations", opts.RawReference, desc.MediaType),
+			Recommendation: fmt.Sprintf(`Only manifests of media type %s or %s can be annotated. Please convert the manifest with "oras cp --convert oci" first.`, ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex),
+		}
+	}
+	annotated, err := manifest.Annotate(manifestBytes, opts.set, opts.removeArguments)
+	if err != nil {
+		if errors.Is(err, manifest.ErrAnnotationNotFound) {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("failed to remove annotation from %s: %w", opts.RawReference, err),
+				Recommendation: `Please check the annotation keys with "oras manifest fetch"`,
+			}
+		}
+		return err
+	}
+
+	old := content.NewDescriptorFromBytes(desc.MediaType, manifestBytes)
+	handler := display.NewManifestAnnotateHandler(opts.Printer)
+	newDesc := content.NewDescriptorFromBytes(desc.MediaType, annotated)
+	if newDesc.Digest == old.Digest {
+		return handler.OnUnchanged(old)
+	}
+	if contentutil.IsDigest(opts.Reference) {
+		newDesc, err = oras.PushBytes(ctx, target, desc.MediaType, annotated)
+	} else {
+		newDesc, err = oras.TagBytes(ctx, target, desc.MediaType, annotated, opts.Reference)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to push the annotated manifest: %w", err)
+	}
+	if err := handler.OnAnnotated(old, newDesc); err != nil {
+		return err
+	}
+	if !contentutil.IsDigest(opts.Reference) {
+		if err := handler.OnTagged(newDesc, opts.Reference); err != nil {
+			return err
+		}
+	}
+
+	if opts.rebaseReferrers {
+		rebaseHandler := display.NewReferrersRebaseHandler(opts.Printer)
+		r := &manifest.Rebaser{
+			Target:  target,
+			Handler: rebaseHandler,
+		}
+		count, err := r.Rebase(ctx, old, newDesc)
+		if err != nil {
+			return err
+		}
+		return rebaseHandler.OnRebaseCompleted(old, newDesc, count)
+	}
+	return nil
+}
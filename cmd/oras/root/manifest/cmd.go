@@ -27,10 +27,14 @@ func Cmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		annotateCmd(),
 		deleteCmd(),
+		diffCmd(),
+		editCmd(),
 		fetchCmd(),
 		fetchConfigCmd(),
 		pushCmd(),
+		validateCmd(),
 		index.Cmd(),
 	)
 	return cmd
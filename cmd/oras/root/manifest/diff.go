@@ -0,0 +1,122 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+type diffOptions struct {
+	option.Common
+	option.Platform
+	option.BinaryTarget
+	option.Format
+}
+
+func diffCmd() *cobra.Command {
+	var opts diffOptions
+	cmd := &cobra.Command{
+		Use:   "diff [flags] <from>{:<tag>|@<digest>} <to>{:<tag>|@<digest>}",
+		Short: "[Experimental] Show the differences between two manifests",
+		Long: `[Experimental] Show the differences between two manifests
+
+Layers are matched by their titles, and the entries of indexes are matched by their platforms. Added, removed and
+changed layers, index entries, config, subject and annotations are shown, as well as the change of the total size of
+the manifests and the content they directly reference. A layer or an index entry is changed if its digest, size or media
+type is changed; changes to its annotations alone, other than its title, are not shown.
+
+Example - Show the differences between two versions of an artifact:
+  oras manifest diff localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Show the differences between the linux/amd64 images of two multi-arch images:
+  oras manifest diff --platform linux/amd64 localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Show the differences in JSON format:
+  oras manifest diff --format json localhost:5000/hello:v1 localhost:5000/hello:v2
+
+Example - Show the differences between an artifact in a registry and one in an OCI image layout folder 'layout-dir':
+  oras manifest diff --to-oci-layout localhost:5000/hello:v1 layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the two manifests to diff"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.From.RawReference = args[0]
+			opts.To.RawReference = args[1]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diffManifests(cmd, &opts)
+		},
+	}
+	opts.SetTypes(
+		option.FormatTypeText,
+		option.FormatTypeJSON.WithUsage("Print the differences in JSON format"),
+	)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.BinaryTarget)
+}
+
+func diffManifests(cmd *cobra.Command, opts *diffOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestDiffHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	from, fromCloser, err := opts.From.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = fromCloser.Close()
+	}()
+	if err := opts.EnsureSourceTargetReferenceNotEmpty(cmd); err != nil {
+		return err
+	}
+	to, toCloser, err := opts.To.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = toCloser.Close()
+	}()
+	if err := opts.To.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+
+	fetchOpts := oras.DefaultFetchBytesOptions
+	fetchOpts.TargetPlatform = opts.Platform.Platform
+	fromDesc, fromContent, err := oras.FetchBytes(ctx, from, opts.From.Reference, fetchOpts)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the content of %q: %w", opts.From.RawReference, err)
+	}
+	toDesc, toContent, err := oras.FetchBytes(ctx, to, opts.To.Reference, fetchOpts)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the content of %q: %w", opts.To.RawReference, err)
+	}
+	diff, err := manifest.DiffManifests(fromDesc, fromContent, toDesc, toContent)
+	if err != nil {
+		return err
+	}
+	return handler.OnDiffed(opts.From.GetDisplayReference(), opts.To.GetDisplayReference(), diff)
+}
@@ -0,0 +1,276 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/registryutil"
+)
+
+type editOptions struct {
+	option.Common
+	option.Target
+}
+
+func editCmd() *cobra.Command {
+	var opts editOptions
+	cmd := &cobra.Command{
+		Use:   "edit [flags] <name>{:<tag>|@<digest>}",
+		Short: "[Experimental] Edit a manifest with an editor and push it",
+		Long: `[Experimental] Edit a manifest with an editor and push it
+
+The manifest is fetched and opened in the editor set by the ORAS_EDITOR or EDITOR environment variables, falling back to
+'vi' on Linux and macOS and 'notepad' on Windows. Once the editor exits, the edited manifest is validated against the
+OCI image-spec and the blobs and manifests it references are checked to exist in the repository. The edited manifest is
+then pushed with its media type, and the tag is moved to it if the manifest is referenced by a tag. The edit is
+cancelled if no change is made.
+
+Example - Edit the manifest tagged 'v1':
+  oras manifest edit localhost:5000/hello:v1
+
+Example - Edit a manifest with a specific editor:
+  ORAS_EDITOR="code --wait" oras manifest edit localhost:5000/hello:v1
+
+Example - Edit a manifest by digest, the edited manifest is pushed without tagging:
+  oras manifest edit localhost:5000/hello@sha256:99e4703fbf30916f549cd6bfa9cdbab614b5392fbe64fdee971359a77073cdf9
+
+Example - Edit a manifest in an OCI image layout folder 'layout-dir':
+  oras manifest edit --oci-layout layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the manifest to edit"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return editManifest(cmd, &opts)
+		},
+	}
+
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func editManifest(cmd *cobra.Command, opts *editOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	target, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	ctx = registryutil.WithScopeHint(ctx, target, auth.ActionPull, auth.ActionPush)
+
+	desc, manifestBytes, err := oras.FetchBytes(ctx, target, opts.Reference, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", opts.RawReference, err)
+	}
+	old := content.NewDescriptorFromBytes(desc.MediaType, manifestBytes)
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, manifestBytes, "", "  "); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", opts.RawReference, err)
+	}
+	pretty.WriteByte('\n')
+
+	// the edited manifest is kept in a temporary file until it is pushed, so
+	// that the changes are not lost if the push fails
+	file, err := os.CreateTemp("", "oras-manifest-*.json")
+	if err != nil {
+		return err
+	}
+	path := file.Name()
+	keep := false
+	defer func() {
+		if !keep {
+			_ = os.Remove(path)
+		}
+	}()
+	_, err = file.Write(pretty.Bytes())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := runEditor(editorCommand(), path); err != nil {
+		return err
+	}
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	handler := display.NewManifestEditHandler(opts.Printer)
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, edited); err != nil {
+		keep = true
+		return savedEditError(fmt.Errorf("the edited manifest is not valid JSON: %w", err), path)
+	}
+	edited = compacted.Bytes()
+	var original bytes.Buffer
+	if err := json.Compact(&original, manifestBytes); err != nil {
+		return err
+	}
+	if bytes.Equal(edited, original.Bytes()) {
+		return handler.OnUnchanged(old)
+	}
+
+	mediaType, err := manifest.ExtractMediaType(edited)
+	if err != nil {
+		if !errors.Is(err, manifest.ErrMediaTypeNotFound) {
+			return err
+		}
+		// the media type is removed from the manifest
+		mediaType = desc.MediaType
+	}
+	if err := checkEdited(ctx, cmd, target, mediaType, edited); err != nil {
+		keep = true
+		return savedEditError(err, path)
+	}
+
+	var newDesc ocispec.Descriptor
+	if contentutil.IsDigest(opts.Reference) {
+		newDesc, err = oras.PushBytes(ctx, target, mediaType, edited)
+	} else {
+		newDesc, err = oras.TagBytes(ctx, target, mediaType, edited, opts.Reference)
+	}
+	if err != nil {
+		keep = true
+		return fmt.Errorf("failed to push the edited manifest saved in %s: %w", path, err)
+	}
+	if err := handler.OnEdited(old, newDesc); err != nil {
+		return err
+	}
+	if !contentutil.IsDigest(opts.Reference) {
+		return handler.OnTagged(newDesc, opts.Reference)
+	}
+	return nil
+}
+
+// savedEditError returns err with a recommendation on the changes saved at
+// path.
+func savedEditError(err error, path string) error {
+	recommendation := fmt.Sprintf(`Your changes are saved in %s and can be pushed with "oras manifest push" once fixed.`, path)
+	var oerr *oerrors.Error
+	if errors.As(err, &oerr) {
+		return &oerrors.Error{
+			Err:            oerr.Err,
+			Recommendation: oerr.Recommendation + " " + recommendation,
+		}
+	}
+	return &oerrors.Error{
+		Err:            err,
+		Recommendation: recommendation,
+	}
+}
+
+// editorCommand returns the command to run the editor of the user.
+func editorCommand() []string {
+	for _, env := range []string{"ORAS_EDITOR", "EDITOR"} {
+		if editor := strings.Fields(os.Getenv(env)); len(editor) > 0 {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// runEditor opens the file at path with editor and waits for it to exit.
+func runEditor(editor []string, path string) error {
+	editorCmd := exec.Command(editor[0], append(editor[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("failed to run editor %q: %w", strings.Join(editor, " "), err),
+			Recommendation: "Please set the editor to use via the environment variable ORAS_EDITOR or EDITOR",
+		}
+	}
+	return nil
+}
+
+// checkEdited validates the edited manifest and checks that the blobs and
+// manifests it references exist in storage.
+func checkEdited(ctx context.Context, cmd *cobra.Command, storage content.ReadOnlyStorage, mediaType string, manifestBytes []byte) error {
+	if err := validateBeforePush(ctx, cmd, "the edited manifest", mediaType, manifestBytes, storage); err != nil {
+		return err
+	}
+	missing, err := missingReferences(ctx, storage, manifestBytes)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		digests := make([]string, len(missing))
+		for i, desc := range missing {
+			digests[i] = desc.Digest.String()
+		}
+		return fmt.Errorf("the edited manifest references content missing from the repository: %s", strings.Join(digests, ", "))
+	}
+	return nil
+}
+
+// missingReferences returns the config, layers and manifests referenced by a
+// manifest or an index that do not exist in storage.
+func missingReferences(ctx context.Context, storage content.ReadOnlyStorage, manifestBytes []byte) ([]ocispec.Descriptor, error) {
+	var references struct {
+		Config    *ocispec.Descriptor  `json:"config"`
+		Layers    []ocispec.Descriptor `json:"layers"`
+		Manifests []ocispec.Descriptor `json:"manifests"`
+	}
+	if err := json.Unmarshal(manifestBytes, &references); err != nil {
+		return nil, err
+	}
+	descs := append(references.Layers, references.Manifests...)
+	if references.Config != nil {
+		descs = append([]ocispec.Descriptor{*references.Config}, descs...)
+	}
+	var missing []ocispec.Descriptor
+	for _, desc := range descs {
+		exists, err := storage.Exists(ctx, desc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check the existence of %s: %w", desc.Digest, err)
+		}
+		if !exists {
+			missing = append(missing, desc)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func Test_missingReferences(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	config := content.NewDescriptorFromBytes(ocispec.MediaTypeEmptyJSON, ocispec.DescriptorEmptyJSON.Data)
+	if err := store.Push(ctx, config, bytes.NewReader(ocispec.DescriptorEmptyJSON.Data)); err != nil {
+		t.Fatal(err)
+	}
+	layer := content.NewDescriptorFromBytes("application/vnd.test", []byte("layer"))
+	if err := store.Push(ctx, layer, bytes.NewReader([]byte("layer"))); err != nil {
+		t.Fatal(err)
+	}
+	missingLayer := content.NewDescriptorFromBytes("application/vnd.test", []byte("missing"))
+	missingManifest := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("{}"))
+
+	tests := []struct {
+		name     string
+		manifest string
+		want     []ocispec.Descriptor
+	}{
+		{
+			name:     "all exist",
+			manifest: `{"config":` + marshal(t, config) + `,"layers":[` + marshal(t, layer) + `]}`,
+		},
+		{
+			name:     "missing layer",
+			manifest: `{"config":` + marshal(t, config) + `,"layers":[` + marshal(t, layer) + `,` + marshal(t, missingLayer) + `]}`,
+			want:     []ocispec.Descriptor{missingLayer},
+		},
+		{
+			name:     "missing manifest",
+			manifest: `{"manifests":[` + marshal(t, missingManifest) + `]}`,
+			want:     []ocispec.Descriptor{missingManifest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := missingReferences(ctx, store, []byte(tt.manifest))
+			if err != nil {
+				t.Fatalf("missingReferences() error = %v", err)
+			}
+			if !slices.EqualFunc(got, tt.want, content.Equal) {
+				t.Errorf("missingReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func marshal(t *testing.T, desc ocispec.Descriptor) string {
+	t.Helper()
+	b, err := json.Marshal(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func Test_editorCommand(t *testing.T) {
+	t.Setenv("ORAS_EDITOR", "")
+	t.Setenv("EDITOR", "code --wait")
+	if got, want := editorCommand(), []string{"code", "--wait"}; !slices.Equal(got, want) {
+		t.Errorf("editorCommand() = %v, want %v", got, want)
+	}
+	t.Setenv("ORAS_EDITOR", "nano")
+	if got, want := editorCommand(), []string{"nano"}; !slices.Equal(got, want) {
+		t.Errorf("editorCommand() = %v, want %v", got, want)
+	}
+}
+
+func Test_runEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sed is not available on windows")
+	}
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := os.WriteFile(path, []byte(`{"key":"old"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := runEditor([]string{"sed", "-i.bak", "s/old/new/"}, path); err != nil {
+		t.Fatalf("runEditor() error = %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"key":"new"}`; string(got) != want {
+		t.Errorf("edited content = %s, want %s", got, want)
+	}
+	if err := runEditor([]string{"false"}, path); err == nil {
+		t.Error("runEditor() should fail when the editor fails")
+	}
+}
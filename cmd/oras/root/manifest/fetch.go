@@ -121,10 +121,13 @@ func fetchManifest(cmd *cobra.Command, opts *fetchOptions) (fetchErr error) {
 		return err
 	}
 
-	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	target, closer, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = closer.Close()
+	}()
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
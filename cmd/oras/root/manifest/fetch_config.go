@@ -92,10 +92,13 @@ Example - Fetch and print the prettified descriptor of the config:
 func fetchConfig(cmd *cobra.Command, opts *fetchConfigOptions) (fetchErr error) {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 
-	repo, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	repo, closer, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = closer.Close()
+	}()
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
@@ -48,6 +48,7 @@ type pushOptions struct {
 	extraRefs   []string
 	fileRef     string
 	mediaType   string
+	validate    bool
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -84,6 +85,9 @@ Example - Push a manifest to repository 'localhost:5000/hello' and tag with 'tag
 Example - Push a manifest to repository 'localhost:5000/hello' and tag with 'tag1', 'tag2', 'tag3' and concurrency level tuned:
   oras manifest push --concurrency 6 localhost:5000/hello:tag1,tag2,tag3 manifest.json
 
+Example - [Experimental] Validate a manifest against the OCI image-spec before pushing it:
+  oras manifest push --validate localhost:5000/hello:v1 manifest.json
+
 Example - Push a manifest to an OCI image layout folder 'layout-dir' and tag with 'v1':
   oras manifest push --oci-layout layout-dir:v1 manifest.json
 
@@ -113,6 +117,7 @@ Example - Push a manifest to an OCI image layout folder 'layout-dir' and tag wit
 	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().StringVarP(&opts.mediaType, "media-type", "", "", "media type of manifest")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().BoolVarP(&opts.validate, "validate", "", false, "[Experimental] validate the manifest against the OCI image-spec before pushing, and abort the push if any error is found")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	return oerrors.Command(cmd, &opts.Target)
@@ -152,6 +157,12 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 		}
 	}
 
+	if opts.validate {
+		if err := validateBeforePush(ctx, cmd, opts.fileRef, mediaType, contentBytes, target); err != nil {
+			return err
+		}
+	}
+
 	// prepare manifest descriptor
 	desc := content.NewDescriptorFromBytes(mediaType, contentBytes)
 	statusHandler, metadataHandler := display.NewManifestPushHandler(opts.Printer, opts.OutputDescriptor, opts.Pretty.Pretty, desc, &opts.Target)
@@ -0,0 +1,196 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/file"
+)
+
+type validateOptions struct {
+	option.Common
+	option.Target
+	option.Format
+
+	fileRef   string
+	mediaType string
+}
+
+func validateCmd() *cobra.Command {
+	var opts validateOptions
+	cmd := &cobra.Command{
+		Use:   "validate [flags] {<file>|<name>{:<tag>|@<digest>}}",
+		Short: "[Experimental] Validate a manifest against the OCI image-spec",
+		Long: `[Experimental] Validate a manifest against the OCI image-spec
+
+The manifest is read from a file if the argument is an existing file or - for stdin, or otherwise fetched from a
+registry or an OCI image layout. The schema, the descriptors, the artifact type, the annotations and the subject of the
+manifest are checked, and each finding is reported with a severity and a recommendation. The subject is only checked
+when the manifest is fetched. The command fails if any error is found.
+
+Example - Validate a manifest file:
+  oras manifest validate manifest.json
+
+Example - Validate a manifest file with a specified media type:
+  oras manifest validate --media-type application/vnd.oci.image.manifest.v1+json manifest.json
+
+Example - Validate a manifest with content read from stdin:
+  oras manifest validate -
+
+Example - Validate a manifest in a registry:
+  oras manifest validate localhost:5000/hello:v1
+
+Example - Validate a manifest and output the findings in JSON format:
+  oras manifest validate --format json localhost:5000/hello:v1
+
+Example - Validate a manifest in an OCI image layout folder 'layout-dir':
+  oras manifest validate --oci-layout layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the manifest file or the manifest to validate"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if isManifestFile(&opts.Target, args[0]) {
+				if args[0] == "-" {
+					if err := option.CheckStdinConflict(cmd.Flags()); err != nil {
+						return err
+					}
+				}
+				// the target flags are not used when validating a file
+				opts.fileRef = args[0]
+				if err := opts.Common.Parse(cmd); err != nil {
+					return err
+				}
+				return opts.Format.Parse(cmd)
+			}
+			if opts.mediaType != "" {
+				return errors.New("--media-type can only be used when validating a file")
+			}
+			opts.RawReference = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validateManifest(cmd, &opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.mediaType, "media-type", "", "", "media type of the manifest file")
+	opts.SetTypes(
+		option.FormatTypeText,
+		option.FormatTypeJSON.WithUsage("Print the findings in JSON format"),
+	)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+// isManifestFile returns true if arg refers to a manifest file rather than a
+// manifest in the target.
+func isManifestFile(target *option.Target, arg string) bool {
+	if target.IsOCILayout || target.Path != "" {
+		return false
+	}
+	if arg == "-" {
+		return true
+	}
+	fi, err := os.Stat(arg)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func validateManifest(cmd *cobra.Command, opts *validateOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestValidateHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	var name, mediaType string
+	var manifestBytes []byte
+	var storage content.ReadOnlyStorage
+	if opts.fileRef != "" {
+		name, mediaType = opts.fileRef, opts.mediaType
+		if manifestBytes, err = file.PrepareManifestContent(opts.fileRef); err != nil {
+			return err
+		}
+	} else {
+		target, closer, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = closer.Close()
+		}()
+		if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+			return err
+		}
+		desc, fetched, err := oras.FetchBytes(ctx, target, opts.Reference, oras.DefaultFetchBytesOptions)
+		if err != nil {
+			return fmt.Errorf("failed to fetch the content of %q: %w", opts.RawReference, err)
+		}
+		name, mediaType, manifestBytes, storage = opts.RawReference, desc.MediaType, fetched, target
+	}
+
+	findings, err := manifest.Validate(ctx, mediaType, manifestBytes, storage)
+	if err != nil {
+		return err
+	}
+	if err := handler.OnValidated(name, findings); err != nil {
+		return err
+	}
+	return validationError(name, findings)
+}
+
+// validateBeforePush validates a manifest before it is pushed. The findings
+// are printed to stderr to keep the output of the push, and an error is
+// returned if any error is found.
+func validateBeforePush(ctx context.Context, cmd *cobra.Command, name, mediaType string, manifestBytes []byte, storage content.ReadOnlyStorage) error {
+	findings, err := manifest.Validate(ctx, mediaType, manifestBytes, storage)
+	if err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		errPrinter := output.NewPrinter(cmd.ErrOrStderr(), cmd.ErrOrStderr())
+		handler, err := display.NewManifestValidateHandler(errPrinter, option.Format{Type: option.FormatTypeText.Name})
+		if err != nil {
+			return err
+		}
+		if err := handler.OnValidated(name, findings); err != nil {
+			return err
+		}
+	}
+	return validationError(name, findings)
+}
+
+// validationError returns an error if any error is found in the manifest.
+func validationError(name string, findings manifest.Findings) error {
+	if count := findings.Count(manifest.SeverityError); count > 0 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%s is invalid: %d error(s) found", name, count),
+			Recommendation: "Please fix the errors reported above.",
+		}
+	}
+	return nil
+}
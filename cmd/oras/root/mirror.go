@@ -0,0 +1,245 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/mirror"
+	"oras.land/oras/internal/registryutil"
+)
+
+type mirrorOptions struct {
+	option.Common
+	option.Remote
+	option.Terminal
+
+	configPath  string
+	statePath   string
+	concurrency int
+}
+
+func mirrorCmd() *cobra.Command {
+	var opts mirrorOptions
+	cmd := &cobra.Command{
+		Use:   "mirror [flags] --config <path>",
+		Short: "[Experimental] Mirror repositories between registries",
+		Long: `[Experimental] Mirror repositories between registries as described by a configuration file
+
+The configuration file is in YAML format and lists the source repositories, their destinations, and optional tag
+filters (regular expressions matching the whole tag), platform filters and whether referrers should be mirrored:
+
+  destination: localhost:6000/mirror
+  includeReferrers: true
+  repositories:
+    - source: localhost:5000/net-monitor
+      tags: ["v1\\..*", "latest"]
+      platforms: ["linux/amd64"]
+    - source: localhost:5000/hello
+      destination: localhost:6000/tools/hello
+      includeReferrers: false
+
+If a repository does not specify a destination, the source repository path is appended to the top-level destination.
+The source digest of each mirrored tag is recorded in a state file along with the platform and referrer settings, so
+that tags which have not changed since the last run are skipped. A repository that fails to be mirrored is reported and
+the remaining repositories are mirrored.
+
+Example - Mirror repositories described in a configuration file:
+  oras mirror --config mirror.yaml
+
+Example - Mirror repositories and keep the state in a specific file:
+  oras mirror --config mirror.yaml --state /var/lib/oras/mirror.state.json
+
+Example - Mirror repositories with concurrency tuned:
+  oras mirror --config mirror.yaml --concurrency 6
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(0), "no arguments"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.statePath == "" {
+				opts.statePath = strings.TrimSuffix(opts.configPath, filepath.Ext(opts.configPath)) + ".state.json"
+			}
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			opts.DisableTTY(opts.Debug, false)
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMirror(cmd, &opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.configPath, "config", "", "", "path to the mirror configuration file")
+	_ = cmd.MarkFlagRequired("config")
+	cmd.Flags().StringVarP(&opts.statePath, "state", "", "", "path to the state file recording mirrored digests, defaults to <config>.state.json next to the configuration file")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	opts.EnableDistributionSpecFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Remote)
+}
+
+func runMirror(cmd *cobra.Command, opts *mirrorOptions) error {
+	startTime := time.Now()
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+
+	cfg, err := mirror.LoadConfig(opts.configPath)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: `Run "oras mirror --help" for an example of the configuration file.`,
+		}
+	}
+	state, err := mirror.LoadState(opts.statePath)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: "Please remove the corrupted state file to mirror all tags again.",
+		}
+	}
+	metadataHandler := display.NewMirrorHandler(opts.Printer)
+
+	var summary metadata.MirrorSummary
+	for i := range cfg.Repositories {
+		if err := mirrorRepository(ctx, &cfg.Repositories[i], state, opts, logger, metadataHandler, &summary); err != nil {
+			return err
+		}
+	}
+
+	if err := metadataHandler.OnMirrorCompleted(summary, time.Since(startTime)); err != nil {
+		return err
+	}
+	if summary.FailedRepositories > 0 {
+		return fmt.Errorf("failed to mirror %d repository(ies) and %d tag(s)", summary.FailedRepositories, summary.Failed)
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("failed to mirror %d tag(s)", summary.Failed)
+	}
+	return nil
+}
+
+// mirrorRepository mirrors the tags of a single repository that match its
+// filters. Failures of the repository and of individual tags are reported and
+// counted in summary without aborting the remaining repositories and tags.
+func mirrorRepository(ctx context.Context, repo *mirror.Repository, state *mirror.State, opts *mirrorOptions, logger logrus.FieldLogger, metadataHandler metadata.MirrorHandler, summary *metadata.MirrorSummary) error {
+	failRepository := func(err error) error {
+		summary.FailedRepositories++
+		return metadataHandler.OnRepositoryFailed(repo.Source, err)
+	}
+	var platforms []*ocispec.Platform
+	for _, p := range repo.Platforms {
+		platform, err := option.ParsePlatform(p)
+		if err != nil {
+			return failRepository(err)
+		}
+		platforms = append(platforms, platform)
+	}
+
+	src, err := opts.NewRepository(repo.Source, opts.Common, logger)
+	if err != nil {
+		return failRepository(err)
+	}
+	dst, err := opts.NewRepository(repo.Destination, opts.Common, logger)
+	if err != nil {
+		return failRepository(err)
+	}
+	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
+
+	var tags []string
+	if err := src.Tags(ctx, "", func(got []string) error {
+		for _, tag := range got {
+			if repo.MatchTag(tag) {
+				tags = append(tags, tag)
+			}
+		}
+		return nil
+	}); err != nil {
+		return failRepository(fmt.Errorf("failed to list tags: %w", err))
+	}
+	if err := metadataHandler.OnTagsFound(repo.Source, repo.Destination, tags); err != nil {
+		return err
+	}
+
+	settings := repo.Settings()
+	for _, tag := range tags {
+		desc, err := oras.Resolve(ctx, src, tag, oras.DefaultResolveOptions)
+		if err != nil {
+			summary.Failed++
+			if err := metadataHandler.OnTagFailed(tag, fmt.Errorf("failed to resolve %q: %w", tag, err)); err != nil {
+				return err
+			}
+			continue
+		}
+		if last, ok := state.Digest(repo.Destination, tag, settings); ok && last == desc.Digest {
+			summary.Skipped++
+			if err := metadataHandler.OnTagSkipped(tag, desc); err != nil {
+				return err
+			}
+			continue
+		}
+
+		copyOpts := &copyOptions{
+			Common:      opts.Common,
+			recursive:   *repo.IncludeReferrers,
+			concurrency: opts.concurrency,
+		}
+		copyOpts.Platforms.Platforms = platforms
+		copyOpts.From = option.Target{
+			Remote:       opts.Remote,
+			RawReference: repo.Source + "@" + desc.Digest.String(),
+			Type:         option.TargetTypeRemote,
+			Reference:    desc.Digest.String(),
+			Path:         repo.Source,
+		}
+		copyOpts.To = option.Target{
+			Remote:       opts.Remote,
+			RawReference: repo.Destination + ":" + tag,
+			Type:         option.TargetTypeRemote,
+			Reference:    tag,
+			Path:         repo.Destination,
+		}
+		statusHandler, _ := display.NewCopyHandler(opts.Printer, opts.TTY, dst)
+		copied, err := doCopy(ctx, statusHandler, src, dst, copyOpts)
+		if err != nil {
+			summary.Failed++
+			if err := metadataHandler.OnTagFailed(tag, oerrors.UnwrapCopyError(err)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := state.Update(repo.Destination, tag, settings, desc.Digest); err != nil {
+			return err
+		}
+		summary.Mirrored++
+		if err := metadataHandler.OnTagMirrored(tag, copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/mirror"
+)
+
+func Test_mirrorRepository_repositoryFailed(t *testing.T) {
+	cfg, err := mirror.ParseConfig([]byte(`
+destination: localhost:6000/mirror
+repositories:
+  - source: localhost:5000/hello
+    platforms: ["linux/amd64/v8/extra"]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := mirror.LoadState(t.TempDir() + "/mirror.state.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	opts := &mirrorOptions{}
+	handler := display.NewMirrorHandler(output.NewPrinter(out, os.Stderr))
+	var summary metadata.MirrorSummary
+	if err := mirrorRepository(context.Background(), &cfg.Repositories[0], state, opts, logrus.New(), handler, &summary); err != nil {
+		t.Fatalf("mirrorRepository() error = %v, want the failure to be recorded", err)
+	}
+	if summary.FailedRepositories != 1 {
+		t.Errorf("FailedRepositories = %d, want 1", summary.FailedRepositories)
+	}
+	if !strings.HasPrefix(out.String(), "Failed to mirror repository localhost:5000/hello: ") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
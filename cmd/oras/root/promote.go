@@ -0,0 +1,250 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/promote"
+	"oras.land/oras/internal/registryutil"
+)
+
+type promoteOptions struct {
+	option.Common
+	option.BinaryTarget
+	option.Terminal
+
+	concurrency int
+	journalPath string
+	rollback    bool
+}
+
+func promoteCmd() *cobra.Command {
+	var opts promoteOptions
+	cmd := &cobra.Command{
+		Use:   "promote [flags] <from>{:<tag>|@<digest>} <to>:<tag>",
+		Short: "[Experimental] Promote an artifact to a tag in another repository",
+		Long: `[Experimental] Promote an artifact to a tag in another repository
+
+The artifact is copied along with its referrers, the digest of the copied manifest is verified against the source, and
+then the destination tag is moved to it. The digest previously tagged is recorded in the local journal specified by
+--journal, so that the promotion can be rolled back with --rollback and the same journal. Repeated rollbacks walk back
+through earlier promotions of the tag.
+
+Example - Promote an artifact from staging to production:
+  oras promote --journal /var/lib/oras/promote.journal localhost:5000/staging/hello:v1.2.0 localhost:6000/prod/hello:stable
+
+Example - Roll back the last promotion of a tag:
+  oras promote --journal /var/lib/oras/promote.journal --rollback localhost:6000/prod/hello:stable
+
+Example - Promote an artifact in an OCI image layout:
+  oras promote --journal promote.journal --from-oci-layout --to-oci-layout layout:v1.2.0 layout:stable
+`,
+		Args: oerrors.CheckArgs(argument.AtLeast(1), "the artifact to promote and the destination tag"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.rollback {
+				if len(args) != 1 {
+					return errors.New("--rollback only accepts the destination tag as the argument")
+				}
+				// the source is not used in a rollback
+				opts.DestinationOnly = true
+				opts.To.RawReference = args[0]
+			} else {
+				if len(args) != 2 {
+					return &oerrors.Error{
+						Err:            fmt.Errorf("expected 2 arguments, got %d", len(args)),
+						Usage:          fmt.Sprintf("%s %s", cmd.Parent().CommandPath(), cmd.Use),
+						Recommendation: "Please specify the artifact to promote and the destination tag, or use --rollback with the destination tag.",
+					}
+				}
+				opts.From.RawReference = args[0]
+				opts.To.RawReference = args[1]
+			}
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			if _, err := digest.Parse(opts.To.Reference); err == nil || opts.To.Reference == "" {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("%q is not a tag", opts.To.RawReference),
+					Recommendation: "Please specify the destination in the form of <name>:<tag>.",
+				}
+			}
+			opts.DisableTTY(opts.Debug, false)
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPromote(cmd, &opts)
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.rollback, "rollback", "", false, "restore the digest tagged before the last promotion of the destination tag")
+	cmd.Flags().StringVarP(&opts.journalPath, "journal", "", "", "path to the local journal recording promotions, which is read by --rollback")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	_ = cmd.MarkFlagRequired("journal")
+	opts.EnableDistributionSpecFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.BinaryTarget)
+}
+
+func runPromote(cmd *cobra.Command, opts *promoteOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	journal, err := promote.Open(opts.journalPath)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: "Please specify a different journal with --journal.",
+		}
+	}
+	dst, err := opts.To.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
+	statusHandler, metadataHandler := display.NewPromoteHandler(opts.Printer, opts.TTY, dst)
+	if opts.rollback {
+		return rollbackPromotion(ctx, dst, journal, metadataHandler, opts)
+	}
+
+	src, closer, err := opts.From.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	if err := opts.EnsureSourceTargetReferenceNotEmpty(cmd); err != nil {
+		return err
+	}
+	return doPromote(ctx, statusHandler, metadataHandler, src, dst, journal, opts)
+}
+
+// doPromote copies the source artifact with its referrers to the destination,
+// verifies the copied manifest and moves the destination tag to it. The
+// previously tagged digest is recorded in the journal.
+func doPromote(ctx context.Context, statusHandler status.CopyHandler, metadataHandler metadata.PromoteHandler, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, journal *promote.Journal, opts *promoteOptions) error {
+	tag := opts.To.Reference
+	previous, err := dst.Resolve(ctx, tag)
+	if err != nil && !errors.Is(err, errdef.ErrNotFound) {
+		return fmt.Errorf("failed to resolve %s: %w", opts.To.RawReference, err)
+	}
+	desc, err := oras.Resolve(ctx, src, opts.From.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.From.RawReference, err)
+	}
+	if previous.Digest == desc.Digest {
+		return metadataHandler.OnUnchanged(&opts.To, desc)
+	}
+
+	copyOpts := &copyOptions{
+		Common:      opts.Common,
+		recursive:   true,
+		concurrency: opts.concurrency,
+	}
+	copyOpts.From = opts.From
+	copyOpts.From.Reference = desc.Digest.String()
+	copyOpts.To = opts.To
+	copyOpts.To.Reference = "" // tagged after verification
+	copied, err := doCopy(ctx, statusHandler, src, dst, copyOpts)
+	if err != nil {
+		return err
+	}
+
+	// verify the manifest in the destination before moving the tag
+	got, err := dst.Resolve(ctx, copied.Digest.String())
+	if err != nil {
+		return fmt.Errorf("failed to verify %s in %s: %w", copied.Digest, opts.To.Path, err)
+	}
+	if got.Digest != desc.Digest || got.Size != desc.Size {
+		return fmt.Errorf("failed to verify %s in %s: got %s of size %d", desc.Digest, opts.To.Path, got.Digest, got.Size)
+	}
+	if err := metadataHandler.OnVerified(&opts.To, got); err != nil {
+		return err
+	}
+
+	if err := dst.Tag(ctx, desc, tag); err != nil {
+		return fmt.Errorf("failed to tag %s with %q: %w", desc.Digest, tag, err)
+	}
+	if err := journal.Append(promote.Entry{
+		Time:       time.Now().UTC(),
+		Action:     promote.ActionPromote,
+		Repository: opts.To.Path,
+		Tag:        tag,
+		Source:     opts.From.RawReference,
+		Previous:   previous.Digest,
+		Digest:     desc.Digest,
+	}); err != nil {
+		return fmt.Errorf("promoted %s but failed to record the previous digest %q: %w", opts.To.RawReference, previous.Digest, err)
+	}
+	return metadataHandler.OnPromoted(&opts.BinaryTarget, previous, desc)
+}
+
+// rollbackPromotion moves the destination tag back to the digest tagged before
+// its last promotion recorded in the journal.
+func rollbackPromotion(ctx context.Context, dst oras.Target, journal *promote.Journal, metadataHandler metadata.PromoteHandler, opts *promoteOptions) error {
+	tag := opts.To.Reference
+	entry, ok := journal.LastPromotion(opts.To.Path, tag)
+	if !ok {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("no promotion of %s is recorded", opts.To.RawReference),
+			Recommendation: "Please specify the journal used for the promotion with --journal.",
+		}
+	}
+	if entry.Previous == "" {
+		return fmt.Errorf("%s did not exist before it was promoted to %s, nothing to roll back to", opts.To.RawReference, entry.Digest)
+	}
+	current, err := dst.Resolve(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.To.RawReference, err)
+	}
+	if current.Digest != entry.Digest {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%s points to %s instead of the promoted %s", opts.To.RawReference, current.Digest, entry.Digest),
+			Recommendation: fmt.Sprintf(`The tag has been moved since the promotion. To restore the previous digest anyway, run "oras tag %s@%s %s".`, opts.To.Path, entry.Previous, tag),
+		}
+	}
+	previous, err := dst.Resolve(ctx, entry.Previous.String())
+	if err != nil {
+		return fmt.Errorf("failed to resolve the previous digest %s: %w", entry.Previous, err)
+	}
+	if err := dst.Tag(ctx, previous, tag); err != nil {
+		return fmt.Errorf("failed to tag %s with %q: %w", previous.Digest, tag, err)
+	}
+	if err := journal.Append(promote.Entry{
+		Time:       time.Now().UTC(),
+		Action:     promote.ActionRollback,
+		Repository: opts.To.Path,
+		Tag:        tag,
+		Previous:   current.Digest,
+		Digest:     previous.Digest,
+	}); err != nil {
+		return err
+	}
+	return metadataHandler.OnRolledBack(&opts.To, current, previous)
+}
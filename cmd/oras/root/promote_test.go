@@ -0,0 +1,179 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/promote"
+)
+
+func Test_doPromote(t *testing.T) {
+	ctx := context.Background()
+	src, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pack := func(artifactType, tag string) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := src.Tag(ctx, desc, tag); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	v1 := pack("application/vnd.test.v1", "v1")
+	v2 := pack("application/vnd.test.v2", "v2")
+	sbom, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{Subject: &v2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	journal, err := promote.Open(filepath.Join(t.TempDir(), "promote.journal"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	printer := output.NewPrinter(io.Discard, io.Discard)
+	statusHandler, metadataHandler := display.NewPromoteHandler(printer, nil, dst)
+	promoteTo := func(from string) {
+		t.Helper()
+		var opts promoteOptions
+		opts.From.RawReference = "staging:" + from
+		opts.From.Reference = from
+		opts.To.RawReference = "prod:stable"
+		opts.To.Reference = "stable"
+		opts.To.Path = "prod"
+		if err := doPromote(ctx, statusHandler, metadataHandler, src, dst, journal, &opts); err != nil {
+			t.Fatalf("doPromote() error = %v", err)
+		}
+	}
+	resolveStable := func() ocispec.Descriptor {
+		t.Helper()
+		desc, err := dst.Resolve(ctx, "stable")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+
+	promoteTo("v1")
+	promoteTo("v2")
+	if got := resolveStable(); got.Digest != v2.Digest {
+		t.Fatalf("stable = %s, want %s", got.Digest, v2.Digest)
+	}
+	if exists, err := dst.Exists(ctx, sbom); err != nil || !exists {
+		t.Error("referrers should be promoted along with the artifact")
+	}
+	entry, ok := journal.LastPromotion("prod", "stable")
+	if !ok || entry.Previous != v1.Digest || entry.Digest != v2.Digest {
+		t.Fatalf("LastPromotion() = %v, %v, want the promotion from %s to %s", entry, ok, v1.Digest, v2.Digest)
+	}
+
+	var opts promoteOptions
+	opts.To.RawReference = "prod:stable"
+	opts.To.Reference = "stable"
+	opts.To.Path = "prod"
+	if err := rollbackPromotion(ctx, dst, journal, metadataHandler, &opts); err != nil {
+		t.Fatalf("rollbackPromotion() error = %v", err)
+	}
+	if got := resolveStable(); got.Digest != v1.Digest {
+		t.Fatalf("stable = %s after rollback, want %s", got.Digest, v1.Digest)
+	}
+	// the tag did not exist before the first promotion
+	if err := rollbackPromotion(ctx, dst, journal, metadataHandler, &opts); err == nil {
+		t.Error("rollbackPromotion() should fail without a previous digest")
+	}
+
+	// the tag is moved by others after the promotion
+	promoteTo("v2")
+	if err := dst.Tag(ctx, v1, "stable"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rollbackPromotion(ctx, dst, journal, metadataHandler, &opts); err == nil {
+		t.Error("rollbackPromotion() should fail if the tag has been moved")
+	}
+}
+
+func Test_promoteCmd(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pack := func(artifactType, tag string) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Tag(ctx, desc, tag); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	v1 := pack("application/vnd.test.v1", "v1")
+	pack("application/vnd.test.v2", "v2")
+	journalPath := filepath.Join(t.TempDir(), "promote.journal")
+	run := func(args ...string) (string, error) {
+		cmd := promoteCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	for _, tag := range []string{"v1", "v2"} {
+		if out, err := run("--journal", journalPath, "--from-oci-layout", "--to-oci-layout", dir+":"+tag, dir+":stable"); err != nil {
+			t.Fatalf("promote %s error = %v, output = %s", tag, err, out)
+		}
+	}
+	// the source is not parsed in a rollback
+	if out, err := run("--journal", journalPath, "--rollback", "--to-oci-layout", dir+":stable"); err != nil {
+		t.Fatalf("rollback error = %v, output = %s", err, out)
+	}
+	store, err = oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.Resolve(ctx, "stable"); err != nil || got.Digest != v1.Digest {
+		t.Errorf("stable = %v, %v after rollback, want %s", got.Digest, err, v1.Digest)
+	}
+
+	// the journal is required
+	if _, err := run("--rollback", "--to-oci-layout", dir+":stable"); err == nil || !strings.Contains(err.Error(), "journal") {
+		t.Errorf("rollback without --journal error = %v, want error", err)
+	}
+}
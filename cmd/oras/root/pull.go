@@ -141,10 +141,13 @@ func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
 	if opts.Platform.Platform != nil {
 		copyOptions.WithTargetPlatform(opts.Platform.Platform)
 	}
-	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	target, closer, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = closer.Close()
+	}()
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
@@ -0,0 +1,137 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package referrers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/manifest"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/registryutil"
+)
+
+type rebaseOptions struct {
+	option.Common
+	option.Target
+
+	newReference string
+	deleteOld    bool
+}
+
+func rebaseCmd() *cobra.Command {
+	var opts rebaseOptions
+	cmd := &cobra.Command{
+		Use:   "rebase [flags] <name>{:<tag>|@<digest>} <name>{:<tag>|@<digest>}",
+		Short: "[Experimental] Rebase referrers of a manifest onto another manifest",
+		Long: `[Experimental] Rebase referrers of a manifest onto another manifest
+
+The subject of each referrer of the old manifest, including the referrers of referrers, is rewritten to point at the new
+manifest, and the rewritten referrer is pushed to the repository. Since rewriting a referrer changes its digest, the
+mapping from old to new referrer digests is printed. Both manifests must be in the same repository.
+
+Example - Rebase referrers of the old image onto the rebuilt image:
+  oras referrers rebase localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5 localhost:5000/hello:v1
+
+Example - Rebase referrers and delete the old referrers afterwards:
+  oras referrers rebase --delete-old localhost:5000/hello:v1-old localhost:5000/hello:v1
+
+Example - Rebase referrers in an OCI image layout folder 'layout-dir':
+  oras referrers rebase --oci-layout layout-dir:v1-old layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the manifest to rebase referrers from and the manifest to rebase referrers onto"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			opts.newReference = args[1]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRebase(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.deleteOld, "delete-old", "", false, "delete the old referrers after rebasing them")
+	opts.EnableDistributionSpecFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runRebase(cmd *cobra.Command, opts *rebaseOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	newTarget, err := opts.WithReference(opts.newReference)
+	if err != nil {
+		return err
+	}
+	if newTarget.Path != opts.Path {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%q and %q are not in the same repository", opts.RawReference, opts.newReference),
+			Recommendation: `Referrers must be stored in the repository of their subject. Please copy the new manifest to the repository of the old one with "oras cp" first.`,
+		}
+	}
+	if err := newTarget.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+
+	target, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	hints := []string{auth.ActionPull, auth.ActionPush}
+	var deleter content.Deleter
+	if opts.deleteOld {
+		var ok bool
+		if deleter, ok = target.(content.Deleter); !ok {
+			return errors.New("the target does not support deleting manifests")
+		}
+		hints = append(hints, auth.ActionDelete)
+	}
+	ctx = registryutil.WithScopeHint(ctx, target, hints...)
+
+	oldSubject, err := oras.Resolve(ctx, target, opts.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.RawReference, err)
+	}
+	newSubject, err := oras.Resolve(ctx, target, newTarget.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.newReference, err)
+	}
+	if oldSubject.Digest == newSubject.Digest {
+		return fmt.Errorf("%s and %s are the same manifest %s", opts.RawReference, opts.newReference, oldSubject.Digest)
+	}
+
+	handler := display.NewReferrersRebaseHandler(opts.Printer)
+	r := &manifest.Rebaser{
+		Target:  target,
+		Deleter: deleter,
+		Handler: handler,
+	}
+	count, err := r.Rebase(ctx, oldSubject, newSubject)
+	if err != nil {
+		return err
+	}
+	return handler.OnRebaseCompleted(oldSubject, newSubject, count)
+}
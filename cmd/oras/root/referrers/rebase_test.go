@@ -0,0 +1,97 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package referrers
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+)
+
+func Test_runRebase(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pack := func(artifactType string, subject *ocispec.Descriptor, tag string) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{
+			Subject: subject,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tag != "" {
+			if err := store.Tag(ctx, desc, tag); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return desc
+	}
+	oldSubject := pack("application/vnd.test.old", nil, "old")
+	newSubject := pack("application/vnd.test.new", nil, "new")
+	sbom := pack("application/vnd.test.sbom", &oldSubject, "")
+
+	run := func(args ...string) (string, error) {
+		cmd := rebaseCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("--oci-layout", dir+":old", filepath.Join(t.TempDir(), "other")+":new"); err == nil || !strings.Contains(err.Error(), "not in the same repository") {
+		t.Errorf("rebase across repositories error = %v, want not in the same repository", err)
+	}
+	if _, err := run("--oci-layout", dir+":old", dir+"@"+oldSubject.Digest.String()); err == nil {
+		t.Error("rebase onto the same manifest should fail")
+	}
+
+	out, err := run("--oci-layout", "--delete-old", dir+":old", dir+":new")
+	if err != nil {
+		t.Fatalf("rebase error = %v, output = %s", err, out)
+	}
+	want := "Rebased 1 referrer(s) from " + oldSubject.Digest.String() + " onto " + newSubject.Digest.String()
+	if !strings.Contains(out, want) {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+
+	store, err = oci.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	referrers, err := registry.Referrers(ctx, store, newSubject, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(referrers) != 1 || referrers[0].ArtifactType != sbom.ArtifactType {
+		t.Errorf("referrers of the new subject = %v, want the rebased sbom", referrers)
+	}
+	if exists, err := store.Exists(ctx, sbom); err != nil || exists {
+		t.Errorf("the old referrer should be deleted: exists = %v, err = %v", exists, err)
+	}
+}
@@ -96,10 +96,13 @@ Example - [Experimental] Show tags of a specific repository in OCI layout:
 
 func showTags(cmd *cobra.Command, opts *showTagsOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
-	finder, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	finder, closer, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = closer.Close()
+	}()
 
 	// if a repository path is given, filter the tags under the repository
 	var targetPrefix string
@@ -65,10 +65,13 @@ Example - Resolve digest of the target artifact:
 
 func runResolve(cmd *cobra.Command, opts *resolveOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
-	repo, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	repo, closer, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = closer.Close()
+	}()
 	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
 		return err
 	}
@@ -19,9 +19,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
@@ -33,7 +39,21 @@ import (
 	"oras.land/oras/cmd/oras/internal/display"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/inventory"
 	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/repository"
+)
+
+const (
+	// artifactTypeRestoreRecord is the artifact type of the restore records
+	// attached as referrers to the artifacts whose tags are renamed on restore.
+	// The restored manifests are kept as is so that their digests, and hence
+	// their signatures and other referrers, stay valid.
+	artifactTypeRestoreRecord = "application/vnd.oras.restore.record"
+	// annotationRestoreSourceTag is the annotation of a restore record,
+	// recording the tag of the restored artifact in the backup.
+	annotationRestoreSourceTag = "land.oras.restore.source-tag"
 )
 
 type restoreOptions struct {
@@ -42,22 +62,33 @@ type restoreOptions struct {
 	option.Terminal
 
 	// flags
-	input            string
+	inputs           []string
 	excludeReferrers bool
 	dryRun           bool
 	concurrency      int
+	repoPrefix       string
+	tagMaps          []string
+	tagPrefix        string
+	tagSuffix        string
+	list             bool
 
 	// derived options
 	repository string
 	tags       []string
+	tagMapping *tagMapping
+	// allRepositories indicates all repositories in the backup are restored
+	// under the namespace of the registry.
+	allRepositories bool
+	hostname        string
+	namespace       string
 }
 
 func restoreCmd() *cobra.Command {
 	var opts restoreOptions
 	cmd := &cobra.Command{
-		Use:   "restore [flags] --input <path> <registry>/<repository>[:<ref1>[,<ref2>...]]",
+		Use:   "restore [flags] --input <path> {<registry>/<repository>[:<ref1>[,<ref2>...]]|<registry>/[<namespace>/]}",
 		Short: "[Experimental] Restore artifacts to a registry from an OCI image layout",
-		Long: `[Experimental] Restore artifacts to a registry from an OCI image layout, which can be either a directory or a tar archive. 
+		Long: `[Experimental] Restore artifacts to a registry from an OCI image layout, which can be either a directory or a tar archive. Compressed tar archives are decompressed transparently.
 
 Example - Restore a single artifact from a tar archive:
   oras restore --input hello.tar localhost:5000/hello:v1
@@ -91,17 +122,71 @@ Example - Restore to a plain HTTP registry (no TLS):
 
 Example - Set custom concurrency level:
   oras restore --input hello --concurrency 6 localhost:5000/hello:v1
+
+Example - Restore from a chain of incremental backups, listed from the full backup to the latest incremental backup:
+  oras restore --input hello-full.tar,hello-mon.tar,hello-tue.tar localhost:5000/hello
+
+Example - Restore from a backup split into volumes, given the first volume:
+  oras restore --input hello.tar.001 localhost:5000/hello
+
+Example - Restore all repositories of a namespace backup to a registry:
+  oras restore --input team.tar localhost:5000/
+
+Example - Restore all repositories of a namespace backup, renaming the "team/" prefix to "mirror/team/":
+  oras restore --input team.tar --repo-prefix team/=mirror/team/ localhost:5000/
+
+Example - Restore all tagged artifacts with the suffix "-dr" appended to the tags, keeping the live tags intact:
+  oras restore --input hello.tar --tag-suffix -dr localhost:5000/hello
+
+Example - Restore the tag "latest" as "v1-restored", attaching a restore record with the original tag to the artifact:
+  oras restore --input hello.tar --tag-map latest=v1-restored localhost:5000/hello:latest
+
+Example - List the inventory of a backup without restoring anything:
+  oras restore --input hello.tar --list
 `,
-		Args: oerrors.CheckArgs(argument.Exactly(1), "the targets to restore to"),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.list {
+				if len(args) > 0 {
+					return &oerrors.Error{
+						Err:            fmt.Errorf("%q does not take a target when --list is specified but got %d argument", cmd.CommandPath(), len(args)),
+						Recommendation: fmt.Sprintf(`Run "%s --input <path> --list" to list the inventory of a backup`, cmd.CommandPath()),
+					}
+				}
+				return nil
+			}
+			return oerrors.CheckArgs(argument.Exactly(1), "the targets to restore to")(cmd, args)
+		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := option.Parse(cmd, &opts); err != nil {
 				return err
 			}
+			if opts.list {
+				opts.DisableTTY(opts.Debug, false)
+				return nil
+			}
 
 			// parse repo and tags
 			var err error
-			opts.repository, opts.tags, err = parseArtifactReferences(args[0])
-			if err != nil {
+			if strings.HasSuffix(args[0], "/") {
+				// restore all repositories under the namespace
+				opts.allRepositories = true
+				opts.repository = args[0]
+				if opts.hostname, opts.namespace, err = repository.ParseRemoteRepository(args[0]); err != nil {
+					return fmt.Errorf("invalid namespace %q: %w", args[0], err)
+				}
+			} else {
+				if opts.repoPrefix != "" {
+					return &oerrors.Error{
+						Err:            errors.New("--repo-prefix can only be used when restoring to a namespace"),
+						Recommendation: `End the target with "/" to restore all repositories of the backup under a namespace, e.g. "localhost:5000/"`,
+					}
+				}
+				opts.repository, opts.tags, err = parseArtifactReferences(args[0])
+				if err != nil {
+					return err
+				}
+			}
+			if opts.tagMapping, err = newTagMapping(opts.tagMaps, opts.tagPrefix, opts.tagSuffix); err != nil {
 				return err
 			}
 
@@ -110,17 +195,25 @@ Example - Set custom concurrency level:
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Printer.Verbose = true // always print verbose output
+			if opts.list {
+				return runRestoreList(&opts)
+			}
 			return runRestore(cmd, &opts)
 		},
 	}
 
 	// required flag
-	cmd.Flags().StringVar(&opts.input, "input", "", "path to the OCI layout, either a tar archive (*.tar) or a directory")
+	cmd.Flags().StringSliceVar(&opts.inputs, "input", nil, "path to the OCI layout, either a tar archive (*.tar, *.tar.gz, *.tar.zst) or a directory. To restore from incremental backups, list the chain from the full backup to the latest incremental backup")
 	_ = cmd.MarkFlagRequired("input")
 	// optional flags
 	cmd.Flags().BoolVar(&opts.excludeReferrers, "exclude-referrers", false, "restore artifacts excluding their referrers")
+	cmd.Flags().BoolVar(&opts.list, "list", false, "[Experimental] print the inventory of the backup, listing its tags, platforms and referrers, without restoring anything")
 	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "simulate the restore process without actually uploading any artifacts")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().StringSliceVar(&opts.tagMaps, "tag-map", nil, "[Experimental] restore the tags under new names, in the form of <old>=<new>")
+	cmd.Flags().StringVar(&opts.tagPrefix, "tag-prefix", "", "[Experimental] prefix prepended to the restored tags, applied after --tag-map")
+	cmd.Flags().StringVar(&opts.tagSuffix, "tag-suffix", "", "[Experimental] suffix appended to the restored tags, applied after --tag-map, e.g. -dr")
+	cmd.Flags().StringVar(&opts.repoPrefix, "repo-prefix", "", "[Experimental] remap the repositories of a namespace backup, either in the form of <old>=<new> to replace the prefix <old> with <new>, or <new> to prepend <new>")
 	opts.EnableDistributionSpecFlag()
 	// apply flags
 	option.ApplyFlags(&opts, cmd.Flags())
@@ -128,62 +221,57 @@ Example - Set custom concurrency level:
 }
 
 func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
-	if opts.input == "" {
+	if len(opts.inputs) == 0 || slices.Contains(opts.inputs, "") {
 		return errors.New("the input path cannot be empty")
 	}
+	// tags are restored from the latest backup in the chain
+	input := opts.inputs[len(opts.inputs)-1]
 	startTime := time.Now() // start timing the restore process
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 
-	// prepare the target registry
-	dstRepo, err := opts.NewRepository(opts.repository, opts.Common, logger)
-	if err != nil {
-		return fmt.Errorf("failed to prepare target repository %q: %w", opts.repository, err)
-	}
-	statusHandler, metadataHandler := display.NewRestoreHandler(opts.Printer, opts.TTY, dstRepo, opts.dryRun)
-
 	// prepare the source OCI store
 	var srcOCI oras.ReadOnlyGraphTarget
-	fi, err := os.Stat(opts.input)
-	if err != nil {
-		return fmt.Errorf("failed to access input path %q: %w", opts.input, err)
-	}
-	switch {
-	case fi.Mode().IsRegular():
-		isTar, err := orasio.IsTarFile(opts.input)
-		if err != nil {
-			return fmt.Errorf("unable to determine if %q is a tar archive: %w", opts.input, err)
-		}
-		if !isTar {
-			return fmt.Errorf("input path %q is not a tar archive", opts.input)
-		}
-		srcOCI, err = oci.NewFromTar(ctx, opts.input)
+	var bases []content.ReadOnlyStorage
+	tarSizes := make([]int64, len(opts.inputs))
+	for i := len(opts.inputs) - 1; i >= 0; i-- {
+		layout, tarSize, closer, err := openOCILayout(ctx, opts.inputs[i])
 		if err != nil {
-			return fmt.Errorf("failed to prepare OCI store from tar archive %q: %w", opts.input, err)
-		}
-		if err := metadataHandler.OnTarLoaded(opts.input, fi.Size()); err != nil {
 			return err
 		}
-	case fi.IsDir():
-		srcOCI, err = oci.NewWithContext(ctx, opts.input)
-		if err != nil {
-			return fmt.Errorf("failed to prepare OCI store from directory %q: %w", opts.input, err)
+		defer func() {
+			_ = closer.Close()
+		}()
+		tarSizes[i] = tarSize
+		if srcOCI == nil {
+			srcOCI = layout
+		} else {
+			bases = append(bases, layout)
+		}
+	}
+	if len(bases) > 0 {
+		// content missing in an incremental backup is read from the earlier
+		// backups in the chain
+		srcOCI = contentutil.NewLayeredTarget(srcOCI, bases...)
+	}
+	statusHandler, metadataHandler := display.NewRestoreHandler(opts.Printer, opts.TTY, srcOCI, opts.dryRun)
+	for i, tarSize := range tarSizes {
+		if tarSize >= 0 {
+			if err := metadataHandler.OnTarLoaded(opts.inputs[i], tarSize); err != nil {
+				return err
+			}
 		}
-	default:
-		return fmt.Errorf("input path %q must be a directory or a tar archive", opts.input)
 	}
 
 	// resolve tags to restore
-	tags, roots, err := resolveTags(ctx, srcOCI, opts.tags)
+	items, err := resolveRestoreItems(ctx, opts, srcOCI, input)
 	if err != nil {
 		return err
 	}
-	if len(tags) == 0 {
-		return &oerrors.Error{
-			Err:            fmt.Errorf("no tags found in OCI layout %q", opts.input),
-			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags --oci-layout"`, opts.input),
-		}
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.name
 	}
-	if err := metadataHandler.OnTagsFound(tags); err != nil {
+	if err := metadataHandler.OnTagsFound(names); err != nil {
 		return err
 	}
 
@@ -199,23 +287,37 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 			return registry.Referrers(ctx, src, desc, "")
 		},
 	}
-	for i, tag := range tags {
+	dstRepos := make(map[string]oras.GraphTarget)
+	for _, item := range items {
 		var referrerCount int
 		if !opts.excludeReferrers {
 			// count referrers from source
-			referrerCount, err = countReferrers(ctx, srcOCI, tag, roots[i], extCopyGraphOpts)
+			referrerCount, err = countReferrers(ctx, srcOCI, item.name, item.root, extCopyGraphOpts)
 			if err != nil {
-				return fmt.Errorf("failed to count referrers for tag %q: %w", tag, err)
+				return fmt.Errorf("failed to count referrers for tag %q: %w", item.name, err)
+			}
+		}
+		if item.tag != item.sourceTag {
+			if err := metadataHandler.OnTagRenamed(item.sourceTag, item.tag); err != nil {
+				return err
 			}
 		}
 		if opts.dryRun {
-			if err := metadataHandler.OnArtifactPushed(tag, referrerCount); err != nil {
+			if err := metadataHandler.OnArtifactPushed(item.name, referrerCount); err != nil {
 				return err
 			}
 			// dry run, skip actual copy
 			continue
 		}
 
+		dstRepo, ok := dstRepos[item.repository]
+		if !ok {
+			dstRepo, err = opts.NewRepository(item.repository, opts.Common, logger)
+			if err != nil {
+				return fmt.Errorf("failed to prepare target repository %q: %w", item.repository, err)
+			}
+			dstRepos[item.repository] = dstRepo
+		}
 		if err := func() (retErr error) {
 			trackedDst, err := statusHandler.StartTracking(dstRepo)
 			if err != nil {
@@ -228,20 +330,297 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 				}
 			}()
 
-			if opts.excludeReferrers {
-				_, err := oras.Copy(ctx, srcOCI, tag, trackedDst, tag, copyOpts)
-				return err
-			}
-			return recursiveCopy(ctx, srcOCI, trackedDst, tag, roots[i], extCopyGraphOpts)
+			return copyRestoreItem(ctx, srcOCI, trackedDst, item, opts.excludeReferrers, copyOpts, extCopyGraphOpts)
 		}(); err != nil {
-			return fmt.Errorf("failed to restore tag %q from %q to %q: %w", tag, opts.input, opts.repository, oerrors.UnwrapCopyError(err))
+			return fmt.Errorf("failed to restore tag %q from %q to %q: %w", item.name, input, item.repository, oerrors.UnwrapCopyError(err))
 		}
 
-		if err := metadataHandler.OnArtifactPushed(tag, referrerCount); err != nil {
+		if err := metadataHandler.OnArtifactPushed(item.name, referrerCount); err != nil {
 			return err
 		}
 	}
 
 	duration := time.Since(startTime)
-	return metadataHandler.OnRestoreCompleted(len(tags), opts.repository, duration)
+	return metadataHandler.OnRestoreCompleted(len(items), opts.repository, duration)
+}
+
+// copyRestoreItem copies the artifact of item from src to dst and tags it in
+// dst. Referrers are copied along unless excludeReferrers is set. If the tag is
+// renamed on restore, a restore record recording the tag in the backup is
+// attached to the artifact.
+func copyRestoreItem(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, item restoreItem, excludeReferrers bool, copyOpts oras.CopyOptions, extCopyGraphOpts oras.ExtendedCopyGraphOptions) error {
+	if excludeReferrers {
+		if _, err := oras.Copy(ctx, src, item.name, dst, item.tag, copyOpts); err != nil {
+			return err
+		}
+	} else if err := recursiveCopy(ctx, src, dst, item.tag, item.root, extCopyGraphOpts); err != nil {
+		return err
+	}
+	if item.tag == item.sourceTag {
+		return nil
+	}
+	_, err := oras.PackManifest(ctx, dst, oras.PackManifestVersion1_1, artifactTypeRestoreRecord, oras.PackManifestOptions{
+		Subject: &item.root,
+		ManifestAnnotations: map[string]string{
+			annotationRestoreSourceTag: item.sourceTag,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record the source tag %q: %w", item.sourceTag, err)
+	}
+	return nil
+}
+
+// runRestoreList prints the inventory of the latest backup in the inputs.
+func runRestoreList(opts *restoreOptions) error {
+	if len(opts.inputs) == 0 || slices.Contains(opts.inputs, "") {
+		return errors.New("the input path cannot be empty")
+	}
+	input := opts.inputs[len(opts.inputs)-1]
+	inv, err := readBackupInventory(input)
+	if err != nil {
+		return err
+	}
+	_, metadataHandler := display.NewRestoreHandler(opts.Printer, opts.TTY, nil, false)
+	return metadataHandler.OnInventoryListed(input, inv)
+}
+
+// readBackupInventory reads the inventory of the backup at path, which is
+// either a directory or a tar archive.
+func readBackupInventory(path string) (*inventory.Inventory, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access input path %q: %w", path, err)
+	}
+	var inventoryJSON []byte
+	if fi.IsDir() {
+		inventoryJSON, err = os.ReadFile(filepath.Join(path, inventory.FileName))
+	} else {
+		inventoryJSON, err = contentutil.ReadFileFromTar(path, inventory.FileName)
+	}
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, &oerrors.Error{
+				Err:            fmt.Errorf("no inventory found in %q", path),
+				Recommendation: fmt.Sprintf(`The backup may be created by an earlier version of oras. To list the tags in %q, use "oras repo tags --oci-layout"`, path),
+			}
+		}
+		return nil, fmt.Errorf("failed to read inventory from %q: %w", path, err)
+	}
+	return inventory.Parse(inventoryJSON)
+}
+
+// restoreItem is an artifact to be restored.
+type restoreItem struct {
+	// name is the reference name of the artifact in the backup.
+	name string
+	root ocispec.Descriptor
+	// repository is the target repository of the artifact.
+	repository string
+	// tag is the tag of the artifact in the target repository.
+	tag string
+	// sourceTag is the tag of the artifact in the backup.
+	sourceTag string
+}
+
+// resolveRestoreItems resolves the artifacts in src to be restored.
+func resolveRestoreItems(ctx context.Context, opts *restoreOptions, src oras.ReadOnlyTarget, input string) ([]restoreItem, error) {
+	var specifiedTags []string
+	if !opts.allRepositories {
+		specifiedTags = opts.tags
+	}
+	names, roots, err := resolveTags(ctx, src, specifiedTags)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, &oerrors.Error{
+			Err:            fmt.Errorf("no tags found in OCI layout %q", input),
+			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags --oci-layout"`, input),
+		}
+	}
+
+	items := make([]restoreItem, len(names))
+	for i, name := range names {
+		items[i] = restoreItem{
+			name:       name,
+			root:       roots[i],
+			repository: opts.repository,
+			sourceTag:  name,
+		}
+		if opts.allRepositories {
+			repo, tag, err := splitRepositoryTag(name)
+			if err != nil {
+				return nil, &oerrors.Error{
+					Err:            err,
+					Recommendation: fmt.Sprintf("Only backups of a namespace can be restored to a namespace. To restore %q, specify the target repository", input),
+				}
+			}
+			if repo, err = remapRepository(repo, opts.repoPrefix); err != nil {
+				return nil, err
+			}
+			items[i].repository = opts.hostname + "/" + opts.namespace + repo
+			items[i].sourceTag = tag
+		}
+		items[i].tag = items[i].sourceTag
+		if opts.tagMapping != nil {
+			if items[i].tag, err = opts.tagMapping.apply(items[i].sourceTag); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return items, nil
+}
+
+// splitRepositoryTag splits a reference name in the form of
+// <repository>:<tag> in a namespace backup.
+func splitRepositoryTag(name string) (string, string, error) {
+	i := strings.LastIndex(name, ":")
+	if i <= 0 || i == len(name)-1 {
+		return "", "", fmt.Errorf("reference name %q is not in the form of <repository>:<tag>", name)
+	}
+	return name[:i], name[i+1:], nil
+}
+
+// remapRepository remaps repo by prefix, which is either in the form of
+// <old>=<new> to replace the prefix <old> with <new>, or <new> to prepend
+// <new>. Repositories not starting with <old> are left unchanged.
+func remapRepository(repo, prefix string) (string, error) {
+	if prefix == "" {
+		return repo, nil
+	}
+	oldPrefix, newPrefix, found := strings.Cut(prefix, "=")
+	if !found {
+		return prefix + repo, nil
+	}
+	if oldPrefix == "" {
+		return "", &oerrors.Error{
+			Err:            fmt.Errorf("invalid repository prefix mapping %q", prefix),
+			Recommendation: `Use the form of <old>=<new> to replace a prefix, or <new> to prepend a prefix`,
+		}
+	}
+	if rest, ok := strings.CutPrefix(repo, oldPrefix); ok {
+		return newPrefix + rest, nil
+	}
+	return repo, nil
+}
+
+// tagMapping renames the tags restored.
+type tagMapping struct {
+	// tagMap maps the tags in the backup to new tags.
+	tagMap map[string]string
+	prefix string
+	suffix string
+}
+
+// newTagMapping creates a tagMapping from the <old>=<new> mappings, the prefix
+// and the suffix. It returns nil if no renaming is specified.
+func newTagMapping(mappings []string, prefix, suffix string) (*tagMapping, error) {
+	if len(mappings) == 0 && prefix == "" && suffix == "" {
+		return nil, nil
+	}
+	m := &tagMapping{
+		tagMap: make(map[string]string, len(mappings)),
+		prefix: prefix,
+		suffix: suffix,
+	}
+	for _, mapping := range mappings {
+		oldTag, newTag, found := strings.Cut(mapping, "=")
+		if !found || oldTag == "" || newTag == "" {
+			return nil, &oerrors.Error{
+				Err:            fmt.Errorf("invalid tag mapping %q", mapping),
+				Recommendation: `Use the form of <old>=<new>, e.g. "--tag-map latest=latest-dr"`,
+			}
+		}
+		if _, ok := m.tagMap[oldTag]; ok {
+			return nil, fmt.Errorf("duplicate tag mapping for %q", oldTag)
+		}
+		m.tagMap[oldTag] = newTag
+	}
+	return m, nil
+}
+
+// apply returns the new name of tag. Tags of the referrers tag schema, in the
+// form of <alg>-<digest>, are kept unchanged as they are looked up by the
+// digest of the subject.
+func (m *tagMapping) apply(tag string) (string, error) {
+	if isReferrersTag(tag) {
+		return tag, nil
+	}
+	renamed := tag
+	if newTag, ok := m.tagMap[tag]; ok {
+		renamed = newTag
+	}
+	renamed = m.prefix + renamed + m.suffix
+	ref := registry.Reference{Reference: renamed}
+	if err := ref.ValidateReferenceAsTag(); err != nil {
+		return "", fmt.Errorf("invalid tag %q renamed from %q: %w", renamed, tag, err)
+	}
+	return renamed, nil
+}
+
+// isReferrersTag returns true if tag is a tag of the referrers tag schema.
+func isReferrersTag(tag string) bool {
+	alg, encoded, found := strings.Cut(tag, "-")
+	if !found {
+		return false
+	}
+	return digest.NewDigestFromEncoded(digest.Algorithm(alg), encoded).Validate() == nil
+}
+
+// openOCILayout opens the OCI layout at path, which is either a directory or a
+// tar archive. The size of the tar archive is returned, or -1 if path is a
+// directory. The returned closer releases the layout once it is no longer used.
+func openOCILayout(ctx context.Context, path string) (oras.ReadOnlyGraphTarget, int64, io.Closer, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to access input path %q: %w", path, err)
+	}
+	switch {
+	case fi.Mode().IsRegular():
+		if base, ok := orasio.VolumeBase(path); ok {
+			// a volume of a split archive
+			manifest, err := orasio.ReadVolumeManifest(base)
+			if err != nil {
+				return nil, 0, nil, err
+			}
+			store, closer, err := contentutil.NewOCIStoreFromTar(ctx, path)
+			if err != nil {
+				if errors.Is(err, orasio.ErrInvalidVolume) {
+					return nil, 0, nil, &oerrors.Error{
+						Err:            fmt.Errorf("failed to read input %q: %w", path, err),
+						Recommendation: fmt.Sprintf("Make sure all the volumes listed in %q are present and intact, copying them again if needed", orasio.VolumeManifestPath(base)),
+					}
+				}
+				return nil, 0, nil, fmt.Errorf("failed to prepare OCI store from volumes of %q: %w", base, err)
+			}
+			return store, manifest.Size(), closer, nil
+		}
+		compression, err := orasio.DetectCompression(path)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("unable to determine if %q is a tar archive: %w", path, err)
+		}
+		if compression == orasio.CompressionNone {
+			isTar, err := orasio.IsTarFile(path)
+			if err != nil {
+				return nil, 0, nil, fmt.Errorf("unable to determine if %q is a tar archive: %w", path, err)
+			}
+			if !isTar {
+				return nil, 0, nil, fmt.Errorf("input path %q is not a tar archive", path)
+			}
+		}
+		store, closer, err := contentutil.NewOCIStoreFromTar(ctx, path)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to prepare OCI store from tar archive %q: %w", path, err)
+		}
+		return store, fi.Size(), closer, nil
+	case fi.IsDir():
+		store, err := oci.NewWithContext(ctx, path)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to prepare OCI store from directory %q: %w", path, err)
+		}
+		return store, -1, contentutil.NopCloser{}, nil
+	default:
+		return nil, 0, nil, fmt.Errorf("input path %q must be a directory or a tar archive", path)
+	}
 }
@@ -0,0 +1,481 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	orasio "oras.land/oras/internal/io"
+)
+
+func Test_splitRepositoryTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		refName  string
+		wantRepo string
+		wantTag  string
+		wantErr  bool
+	}{
+		{name: "repository and tag", refName: "team/app:v1", wantRepo: "team/app", wantTag: "v1"},
+		{name: "nested repository", refName: "team/a/b:latest", wantRepo: "team/a/b", wantTag: "latest"},
+		{name: "tag only", refName: "v1", wantErr: true},
+		{name: "empty repository", refName: ":v1", wantErr: true},
+		{name: "empty tag", refName: "team/app:", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, tag, err := splitRepositoryTag(tt.refName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitRepositoryTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repo != tt.wantRepo || tag != tt.wantTag {
+				t.Errorf("splitRepositoryTag() = (%q, %q), want (%q, %q)", repo, tag, tt.wantRepo, tt.wantTag)
+			}
+		})
+	}
+}
+
+func Test_remapRepository(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    string
+		prefix  string
+		want    string
+		wantErr bool
+	}{
+		{name: "no prefix", repo: "team/app", want: "team/app"},
+		{name: "prepend", repo: "team/app", prefix: "mirror/", want: "mirror/team/app"},
+		{name: "replace", repo: "team/app", prefix: "team/=mirror/team/", want: "mirror/team/app"},
+		{name: "replace with empty", repo: "team/app", prefix: "team/=", want: "app"},
+		{name: "replace not matched", repo: "other/app", prefix: "team/=mirror/", want: "other/app"},
+		{name: "empty old prefix", repo: "team/app", prefix: "=mirror/", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := remapRepository(tt.repo, tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("remapRepository() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("remapRepository() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resolveRestoreItems(t *testing.T) {
+	ctx := context.Background()
+	src, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"team/app:v1", "team/lib:v2"} {
+		if err := src.Tag(ctx, desc, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("namespace", func(t *testing.T) {
+		opts := &restoreOptions{
+			repository:      "localhost:5000/ns/",
+			allRepositories: true,
+			hostname:        "localhost:5000",
+			namespace:       "ns/",
+			repoPrefix:      "team/=mirror/",
+		}
+		items, err := resolveRestoreItems(ctx, opts, src, "backup")
+		if err != nil {
+			t.Fatalf("resolveRestoreItems() error = %v", err)
+		}
+		want := []restoreItem{
+			{name: "team/app:v1", root: desc, repository: "localhost:5000/ns/mirror/app", tag: "v1", sourceTag: "v1"},
+			{name: "team/lib:v2", root: desc, repository: "localhost:5000/ns/mirror/lib", tag: "v2", sourceTag: "v2"},
+		}
+		if !reflect.DeepEqual(items, want) {
+			t.Errorf("resolveRestoreItems() = %+v, want %+v", items, want)
+		}
+	})
+
+	t.Run("namespace with plain tags", func(t *testing.T) {
+		if err := src.Tag(ctx, desc, "v1"); err != nil {
+			t.Fatal(err)
+		}
+		opts := &restoreOptions{
+			repository:      "localhost:5000/",
+			allRepositories: true,
+			hostname:        "localhost:5000",
+		}
+		if _, err := resolveRestoreItems(ctx, opts, src, "backup"); err == nil {
+			t.Error("resolveRestoreItems() error = nil, want error")
+		}
+	})
+
+	t.Run("repository", func(t *testing.T) {
+		opts := &restoreOptions{
+			repository: "localhost:5000/app",
+			tags:       []string{"v1"},
+		}
+		items, err := resolveRestoreItems(ctx, opts, src, "backup")
+		if err != nil {
+			t.Fatalf("resolveRestoreItems() error = %v", err)
+		}
+		want := []restoreItem{{name: "v1", root: desc, repository: "localhost:5000/app", tag: "v1", sourceTag: "v1"}}
+		if !reflect.DeepEqual(items, want) {
+			t.Errorf("resolveRestoreItems() = %+v, want %+v", items, want)
+		}
+	})
+
+	t.Run("namespace with renamed tags", func(t *testing.T) {
+		tagMapping, err := newTagMapping([]string{"v1=stable"}, "", "-dr")
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := &restoreOptions{
+			repository:      "localhost:5000/",
+			allRepositories: true,
+			hostname:        "localhost:5000",
+			tagMapping:      tagMapping,
+		}
+		if err := src.Untag(ctx, "v1"); err != nil {
+			t.Fatal(err)
+		}
+		items, err := resolveRestoreItems(ctx, opts, src, "backup")
+		if err != nil {
+			t.Fatalf("resolveRestoreItems() error = %v", err)
+		}
+		want := []restoreItem{
+			{name: "team/app:v1", root: desc, repository: "localhost:5000/team/app", tag: "stable-dr", sourceTag: "v1"},
+			{name: "team/lib:v2", root: desc, repository: "localhost:5000/team/lib", tag: "v2-dr", sourceTag: "v2"},
+		}
+		if !reflect.DeepEqual(items, want) {
+			t.Errorf("resolveRestoreItems() = %+v, want %+v", items, want)
+		}
+	})
+}
+
+// newRestoreTestRegistry returns a registry serving the repository "test",
+// which keeps the pushed blobs and manifests in memory. The referrers API is
+// not supported so that referrers are indexed by the referrers tag schema.
+func newRestoreTestRegistry(t *testing.T) *httptest.Server {
+	t.Helper()
+	var lock sync.Mutex
+	blobs := make(map[string][]byte)
+	manifests := make(map[string][]byte)
+	mediaTypes := make(map[string]string)
+	serveContent := func(w http.ResponseWriter, r *http.Request, mediaType string, content []byte) {
+		w.Header().Set("Content-Type", mediaType)
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(content).String())
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Docker-Distribution-Api-Version", "registry/2.0")
+	})
+	mux.HandleFunc("/v2/test/blobs/uploads/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/v2/test/blobs/uploads/upload")
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodPut:
+			content, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			dgst := r.URL.Query().Get("digest")
+			if digest.FromBytes(content).String() != dgst {
+				http.Error(w, "digest mismatch", http.StatusBadRequest)
+				return
+			}
+			lock.Lock()
+			blobs[dgst] = content
+			lock.Unlock()
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/v2/test/blobs/{digest}", func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		content, ok := blobs[r.PathValue("digest")]
+		lock.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		serveContent(w, r, "application/octet-stream", content)
+	})
+	mux.HandleFunc("/v2/test/manifests/{reference}", func(w http.ResponseWriter, r *http.Request) {
+		reference := r.PathValue("reference")
+		lock.Lock()
+		defer lock.Unlock()
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			content, ok := manifests[reference]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			serveContent(w, r, mediaTypes[digest.FromBytes(content).String()], content)
+		case http.MethodPut:
+			content, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			dgst := digest.FromBytes(content).String()
+			manifests[reference] = content
+			manifests[dgst] = content
+			mediaTypes[dgst] = r.Header.Get("Content-Type")
+			w.Header().Set("Docker-Content-Digest", dgst)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			if _, ok := manifests[reference]; !ok {
+				http.NotFound(w, r)
+				return
+			}
+			delete(manifests, reference)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_copyRestoreItem(t *testing.T) {
+	ctx := context.Background()
+	src, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	referrer, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sig", oras.PackManifestOptions{Subject: &root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	extCopyGraphOpts := oras.ExtendedCopyGraphOptions{
+		FindPredecessors: func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+			return registry.Referrers(ctx, src, desc, "")
+		},
+	}
+
+	tests := []struct {
+		name             string
+		tag              string
+		excludeReferrers bool
+		wantSourceTag    string
+	}{
+		{name: "same tag", tag: "v1"},
+		{name: "renamed tag", tag: "v1-dr", wantSourceTag: "v1"},
+		{name: "renamed tag without referrers", tag: "v1-dr", excludeReferrers: true, wantSourceTag: "v1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRestoreTestRegistry(t)
+			uri, err := url.Parse(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dst, err := remote.NewRepository(uri.Host + "/test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			dst.PlainHTTP = true
+			item := restoreItem{name: "v1", root: root, tag: tt.tag, sourceTag: "v1"}
+			if err := copyRestoreItem(ctx, src, dst, item, tt.excludeReferrers, oras.DefaultCopyOptions, extCopyGraphOpts); err != nil {
+				t.Fatalf("copyRestoreItem() error = %v", err)
+			}
+			got, err := dst.Resolve(ctx, tt.tag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Digest != root.Digest {
+				t.Errorf("tag %q resolved to %s, want %s", tt.tag, got.Digest, root.Digest)
+			}
+			exists, err := dst.Exists(ctx, referrer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exists == tt.excludeReferrers {
+				t.Errorf("referrer exists = %v, want %v", exists, !tt.excludeReferrers)
+			}
+
+			// the source tag is recorded by a referrer kept in the registry
+			records, err := registry.Referrers(ctx, dst, root, artifactTypeRestoreRecord)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantSourceTag == "" {
+				if len(records) != 0 {
+					t.Errorf("got %d restore records, want none", len(records))
+				}
+				return
+			}
+			if len(records) != 1 {
+				t.Fatalf("got %d restore records, want 1", len(records))
+			}
+			if sourceTag := records[0].Annotations[annotationRestoreSourceTag]; sourceTag != tt.wantSourceTag {
+				t.Errorf("annotation %s = %q, want %q", annotationRestoreSourceTag, sourceTag, tt.wantSourceTag)
+			}
+		})
+	}
+}
+
+func Test_tagMapping(t *testing.T) {
+	referrersTag := "sha256-" + strings.Repeat("a", 64)
+	tests := []struct {
+		name     string
+		mappings []string
+		prefix   string
+		suffix   string
+		tag      string
+		want     string
+		wantNil  bool
+		wantErr  bool
+	}{
+		{name: "no renaming", tag: "v1", wantNil: true},
+		{name: "mapped", mappings: []string{"latest=stable"}, tag: "latest", want: "stable"},
+		{name: "not mapped", mappings: []string{"latest=stable"}, tag: "v1", want: "v1"},
+		{name: "prefix", prefix: "dr-", tag: "v1", want: "dr-v1"},
+		{name: "suffix", suffix: "-dr", tag: "v1", want: "v1-dr"},
+		{name: "mapped with prefix and suffix", mappings: []string{"latest=stable"}, prefix: "dr-", suffix: "-1", tag: "latest", want: "dr-stable-1"},
+		{name: "referrers tag schema kept", suffix: "-dr", tag: referrersTag, want: referrersTag},
+		{name: "invalid renamed tag", prefix: "-", tag: "v1", wantErr: true},
+		{name: "invalid mapping", mappings: []string{"latest"}, wantErr: true},
+		{name: "empty new tag", mappings: []string{"latest="}, wantErr: true},
+		{name: "duplicate mapping", mappings: []string{"latest=a", "latest=b"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newTagMapping(tt.mappings, tt.prefix, tt.suffix)
+			if err == nil && m != nil {
+				var got string
+				got, err = m.apply(tt.tag)
+				if err == nil && got != tt.want {
+					t.Errorf("apply() = %q, want %q", got, tt.want)
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (m == nil) != tt.wantNil {
+				t.Errorf("newTagMapping() = %v, wantNil %v", m, tt.wantNil)
+			}
+		})
+	}
+}
+
+func Test_openOCILayout_compressed(t *testing.T) {
+	ctx := context.Background()
+	layoutDir := t.TempDir()
+	store, err := oci.New(layoutDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Tag(ctx, desc, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name        string
+		compression orasio.Compression
+	}{
+		{name: "layout.tar", compression: orasio.CompressionNone},
+		{name: "layout.tar.gz", compression: orasio.CompressionGzip},
+		{name: "layout.tar.zst", compression: orasio.CompressionZstd},
+		// compression is detected by content rather than file extension
+		{name: "layout", compression: orasio.CompressionGzip},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.name)
+			fp, err := os.Create(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := writeBackupTar(fp, layoutDir, tt.compression); err != nil {
+				t.Fatalf("writeBackupTar() error = %v", err)
+			}
+			if err := fp.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			layout, tarSize, closer, err := openOCILayout(ctx, path)
+			if err != nil {
+				t.Fatalf("openOCILayout() error = %v", err)
+			}
+			if tarSize <= 0 {
+				t.Errorf("openOCILayout() tarSize = %d, want positive", tarSize)
+			}
+			got, err := layout.Resolve(ctx, "v1")
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if got.Digest != desc.Digest {
+				t.Errorf("Resolve() digest = %v, want %v", got.Digest, desc.Digest)
+			}
+			exists, err := layout.Exists(ctx, desc)
+			if err != nil || !exists {
+				t.Errorf("Exists() = (%v, %v), want (true, nil)", exists, err)
+			}
+			if err := closer.Close(); err != nil {
+				t.Errorf("Close() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package checkpoint records the progress of long running copies so that an
+// interrupted operation can be resumed from the remaining work.
+package checkpoint
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// version is the version of the checkpoint file format.
+const version = 1
+
+// ErrMismatch is returned when a checkpoint file is recorded for a different
+// operation.
+var ErrMismatch = errors.New("checkpoint is recorded for a different operation")
+
+// record is a line in the checkpoint file.
+type record struct {
+	// Version and Operation are only set in the header line.
+	Version   int    `json:"version,omitempty"`
+	Operation string `json:"operation,omitempty"`
+	// Node is the digest of a completed node.
+	Node digest.Digest `json:"node,omitempty"`
+	// Tag is the name of a completed tag.
+	Tag *Tag `json:"tag,omitempty"`
+}
+
+// Tag is a completed tag.
+type Tag struct {
+	Name          string             `json:"name"`
+	Descriptor    ocispec.Descriptor `json:"descriptor"`
+	ReferrerCount int                `json:"referrerCount"`
+}
+
+// Checkpoint is an append-only log of completed nodes and tags, stored as a
+// file with one JSON record per line.
+type Checkpoint struct {
+	path string
+	file *os.File
+	lock sync.Mutex
+
+	nodes map[digest.Digest]struct{}
+	tags  map[string]Tag
+}
+
+// Open opens the checkpoint file at path for the operation, which is an
+// identifier of the operation, such as the source and the destination. The
+// file is created if it does not exist. Records of an existing file are
+// loaded, and ErrMismatch is returned if the file is recorded for a different
+// operation.
+func Open(path, operation string) (*Checkpoint, error) {
+	c := &Checkpoint{
+		path:  path,
+		nodes: make(map[digest.Digest]struct{}),
+		tags:  make(map[string]Tag),
+	}
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if len(content) > 0 {
+		if err := c.load(content, operation); err != nil {
+			return nil, err
+		}
+	}
+
+	c.file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	if len(content) == 0 {
+		if err := c.append(record{Version: version, Operation: operation}); err != nil {
+			_ = c.file.Close()
+			return nil, err
+		}
+	} else if content[len(content)-1] != '\n' {
+		// terminate the partially written record of an interrupted run
+		if _, err := c.file.Write([]byte{'\n'}); err != nil {
+			_ = c.file.Close()
+			return nil, fmt.Errorf("failed to write checkpoint: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// load loads the records in content.
+func (c *Checkpoint) load(content []byte, operation string) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, 1024*1024)
+	for line := 0; scanner.Scan(); line++ {
+		var r record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			// the last record may be partially written if the previous run
+			// was interrupted
+			continue
+		}
+		if line == 0 {
+			if r.Version != version {
+				return fmt.Errorf("unsupported checkpoint version %d in %q", r.Version, c.path)
+			}
+			if r.Operation != operation {
+				return fmt.Errorf("%w: %q is recorded for %q", ErrMismatch, c.path, r.Operation)
+			}
+			continue
+		}
+		if r.Node != "" {
+			c.nodes[r.Node] = struct{}{}
+		}
+		if r.Tag != nil {
+			c.tags[r.Tag.Name] = *r.Tag
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return nil
+}
+
+// append writes a record to the checkpoint file.
+func (c *Checkpoint) append(r record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// IsNodeDone returns true if the node is recorded as completed.
+func (c *Checkpoint) IsNodeDone(desc ocispec.Descriptor) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, ok := c.nodes[desc.Digest]
+	return ok
+}
+
+// NodeDone records the node as completed.
+func (c *Checkpoint) NodeDone(desc ocispec.Descriptor) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.nodes[desc.Digest]; ok {
+		return nil
+	}
+	if err := c.append(record{Node: desc.Digest}); err != nil {
+		return err
+	}
+	c.nodes[desc.Digest] = struct{}{}
+	return nil
+}
+
+// Tag returns the tag recorded as completed.
+func (c *Checkpoint) Tag(name string) (Tag, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tag, ok := c.tags[name]
+	return tag, ok
+}
+
+// TagDone records the tag as completed.
+func (c *Checkpoint) TagDone(tag Tag) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := c.append(record{Tag: &tag}); err != nil {
+		return err
+	}
+	c.tags[tag.Name] = tag
+	return nil
+}
+
+// Close closes the checkpoint file.
+func (c *Checkpoint) Close() error {
+	return c.file.Close()
+}
+
+// Remove closes and removes the checkpoint file. It is called when the
+// operation is completed and there is nothing left to resume.
+func (c *Checkpoint) Remove() error {
+	if err := c.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestCheckpoint_resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	node := content.NewDescriptorFromBytes("application/octet-stream", []byte("node"))
+	root := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("root"))
+
+	c, err := Open(path, "backup a to b")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if c.IsNodeDone(node) {
+		t.Fatal("IsNodeDone() = true for a new checkpoint")
+	}
+	if err := c.NodeDone(node); err != nil {
+		t.Fatalf("NodeDone() error = %v", err)
+	}
+	if err := c.TagDone(Tag{Name: "v1", Descriptor: root, ReferrerCount: 2}); err != nil {
+		t.Fatalf("TagDone() error = %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a partially written record of an interrupted run
+	fp, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fp.WriteString(`{"node":"sha256:`); err != nil {
+		t.Fatal(err)
+	}
+	if err := fp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = Open(path, "backup a to b")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if !c.IsNodeDone(node) {
+		t.Error("IsNodeDone() = false for a recorded node")
+	}
+	tag, ok := c.Tag("v1")
+	if !ok || tag.Descriptor.Digest != root.Digest || tag.ReferrerCount != 2 {
+		t.Errorf("Tag() = %+v, %v", tag, ok)
+	}
+	if err := c.NodeDone(root); err != nil {
+		t.Fatalf("NodeDone() error = %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = Open(path, "backup a to b")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if !c.IsNodeDone(root) {
+		t.Error("record appended after a partial record is lost")
+	}
+	if err := c.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("checkpoint file is not removed: %v", err)
+	}
+}
+
+func TestOpen_mismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	c, err := Open(path, "backup a to b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path, "backup c to d"); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Open() error = %v, want %v", err, ErrMismatch)
+	}
+}
+
+func TestNewTarget(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	c, err := Open(path, "copy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	src := memory.New()
+	blob := []byte("blob")
+	desc, err := oras.PushBytes(ctx, src, "application/octet-stream", blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := memory.New()
+	opts := oras.DefaultCopyGraphOptions
+	var copied int
+	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		copied++
+		return nil
+	}
+	Hook(&opts, c)
+	if err := oras.CopyGraph(ctx, src, NewTarget(dst, c), desc, opts); err != nil {
+		t.Fatalf("CopyGraph() error = %v", err)
+	}
+	if copied != 1 || !c.IsNodeDone(desc) {
+		t.Fatalf("copied node is not recorded: copied = %d", copied)
+	}
+
+	// completed nodes are reported as existing even if missing in the target
+	empty := memory.New()
+	exists, err := NewTarget(empty, c).Exists(ctx, desc)
+	if err != nil || !exists {
+		t.Errorf("Exists() = %v, %v, want true", exists, err)
+	}
+	if err := oras.CopyGraph(ctx, src, NewTarget(empty, c), desc, opts); err != nil {
+		t.Fatalf("CopyGraph() error = %v", err)
+	}
+	if copied != 1 {
+		t.Errorf("completed node is copied again")
+	}
+	if exists, _ := empty.Exists(ctx, desc); exists {
+		t.Errorf("completed node should be skipped")
+	}
+	if got := bytes.Count(mustRead(t, path), []byte("\n")); got != 2 {
+		t.Errorf("checkpoint has %d records, want 2", got)
+	}
+}
+
+func mustRead(t *testing.T, path string) []byte {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
@@ -0,0 +1,81 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+)
+
+// target is a GraphTarget reporting completed nodes as existing without
+// querying the underlying target.
+type target struct {
+	oras.GraphTarget
+	checkpoint *Checkpoint
+}
+
+// NewTarget returns a GraphTarget wrapping gt. Nodes recorded as completed in
+// the checkpoint are reported as existing, so that they are skipped along with
+// their successors during copy.
+func NewTarget(gt oras.GraphTarget, checkpoint *Checkpoint) oras.GraphTarget {
+	return &target{
+		GraphTarget: gt,
+		checkpoint:  checkpoint,
+	}
+}
+
+// Exists returns true if the node is recorded as completed, or if it exists
+// in the underlying target.
+func (t *target) Exists(ctx context.Context, desc ocispec.Descriptor) (bool, error) {
+	if t.checkpoint.IsNodeDone(desc) {
+		return true, nil
+	}
+	return t.GraphTarget.Exists(ctx, desc)
+}
+
+// Hook wraps the copy callbacks in opts to record the copied, skipped and
+// mounted nodes as completed.
+func Hook(opts *oras.CopyGraphOptions, checkpoint *Checkpoint) {
+	postCopy := opts.PostCopy
+	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if postCopy != nil {
+			if err := postCopy(ctx, desc); err != nil {
+				return err
+			}
+		}
+		return checkpoint.NodeDone(desc)
+	}
+	onCopySkipped := opts.OnCopySkipped
+	opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if onCopySkipped != nil {
+			if err := onCopySkipped(ctx, desc); err != nil {
+				return err
+			}
+		}
+		return checkpoint.NodeDone(desc)
+	}
+	onMounted := opts.OnMounted
+	opts.OnMounted = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if onMounted != nil {
+			if err := onMounted(ctx, desc); err != nil {
+				return err
+			}
+		}
+		return checkpoint.NodeDone(desc)
+	}
+}
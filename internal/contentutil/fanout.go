@@ -0,0 +1,276 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// errAllTargetsFailed is returned when no target is left to write to.
+var errAllTargetsFailed = errors.New("failed to write to all targets")
+
+// FanOutTarget is a GraphTarget that writes content to multiple targets at
+// once. Content pushed to a FanOutTarget is read only once and streamed to all
+// targets concurrently.
+//
+// A target is excluded from later operations once an operation on it fails.
+// The failure is returned immediately only if the FanOutTarget is fail-fast,
+// or if all targets have failed.
+type FanOutTarget struct {
+	targets  []oras.GraphTarget
+	failFast bool
+
+	lock sync.RWMutex
+	errs []error
+	// missing records the targets missing the content found by Exists, so
+	// that Push does not check the existence again.
+	missing map[digest.Digest][]int
+}
+
+// NewFanOutTarget returns a FanOutTarget writing to targets.
+func NewFanOutTarget(failFast bool, targets ...oras.GraphTarget) *FanOutTarget {
+	return &FanOutTarget{
+		targets:  targets,
+		failFast: failFast,
+		errs:     make([]error, len(targets)),
+		missing:  make(map[digest.Digest][]int),
+	}
+}
+
+// Err returns the error occurred on the i-th target, if any.
+func (t *FanOutTarget) Err(i int) error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.errs[i]
+}
+
+// active returns the indices of the targets without failures.
+func (t *FanOutTarget) active() []int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	var indices []int
+	for i, err := range t.errs {
+		if err == nil {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// fail records err for the i-th target. The error to be returned to the caller
+// is returned.
+func (t *FanOutTarget) fail(i int, err error) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.errs[i] == nil {
+		t.errs[i] = err
+	}
+	if t.failFast {
+		return err
+	}
+	for _, err := range t.errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Join(errAllTargetsFailed, err)
+}
+
+// first returns the first target without failures.
+func (t *FanOutTarget) first() (oras.GraphTarget, error) {
+	active := t.active()
+	if len(active) == 0 {
+		return nil, errAllTargetsFailed
+	}
+	return t.targets[active[0]], nil
+}
+
+// Exists returns true only if the content exists in all targets without
+// failures. The targets are checked concurrently, and the targets missing the
+// content are remembered for the following Push.
+func (t *FanOutTarget) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	missing, err := t.checkMissing(ctx, target)
+	if err != nil {
+		return false, err
+	}
+	if len(missing) == 0 {
+		return true, nil
+	}
+	t.lock.Lock()
+	t.missing[target.Digest] = missing
+	t.lock.Unlock()
+	return false, nil
+}
+
+// checkMissing checks the existence of the content in all targets without
+// failures concurrently, and returns the indices of the targets missing it.
+func (t *FanOutTarget) checkMissing(ctx context.Context, target ocispec.Descriptor) ([]int, error) {
+	active := t.active()
+	if len(active) == 0 {
+		return nil, errAllTargetsFailed
+	}
+	results := make([]bool, len(active))
+	errs := make([]error, len(active))
+	var wg sync.WaitGroup
+	for j, i := range active {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[j], errs[j] = t.targets[i].Exists(ctx, target)
+		}()
+	}
+	wg.Wait()
+
+	var missing []int
+	for j, i := range active {
+		if errs[j] != nil {
+			if err := t.fail(i, errs[j]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !results[j] {
+			missing = append(missing, i)
+		}
+	}
+	return missing, nil
+}
+
+// Push reads the content from r once and pushes it to all targets without
+// failures and missing the content.
+func (t *FanOutTarget) Push(ctx context.Context, expected ocispec.Descriptor, r io.Reader) error {
+	t.lock.Lock()
+	missing, checked := t.missing[expected.Digest]
+	delete(t.missing, expected.Digest)
+	t.lock.Unlock()
+	if !checked {
+		var err error
+		if missing, err = t.checkMissing(ctx, expected); err != nil {
+			return err
+		}
+	}
+	// targets may have failed since the existence check
+	var indices []int
+	for _, i := range missing {
+		if t.Err(i) == nil {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) == 0 {
+		_, err := io.Copy(io.Discard, r)
+		return err
+	}
+
+	var wg sync.WaitGroup
+	writers := make([]*io.PipeWriter, len(indices))
+	pushErrs := make([]error, len(indices))
+	for j, i := range indices {
+		pr, pw := io.Pipe()
+		writers[j] = pw
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pushErrs[j] = t.targets[i].Push(ctx, expected, pr)
+			// unblock the writer in case the push returns early
+			_ = pr.CloseWithError(pushErrs[j])
+		}()
+	}
+
+	// stream the content to all writers, skipping the failed ones
+	writeErrs := make([]error, len(indices))
+	buf := make([]byte, 32*1024)
+	var readErr error
+	for readErr == nil {
+		var n int
+		n, readErr = r.Read(buf)
+		if n > 0 {
+			for j, w := range writers {
+				if writeErrs[j] == nil {
+					_, writeErrs[j] = w.Write(buf[:n])
+				}
+			}
+		}
+	}
+	if readErr == io.EOF {
+		readErr = nil
+	}
+	for _, w := range writers {
+		_ = w.CloseWithError(readErr)
+	}
+	wg.Wait()
+	if readErr != nil {
+		return readErr
+	}
+
+	for j, i := range indices {
+		if pushErrs[j] == nil || errors.Is(pushErrs[j], errdef.ErrAlreadyExists) {
+			continue
+		}
+		if err := t.fail(i, fmt.Errorf("failed to push %s: %w", expected.Digest, pushErrs[j])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fetch fetches the content from the first target without failures.
+func (t *FanOutTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	first, err := t.first()
+	if err != nil {
+		return nil, err
+	}
+	return first.Fetch(ctx, target)
+}
+
+// Predecessors returns the predecessors of node from the first target without
+// failures.
+func (t *FanOutTarget) Predecessors(ctx context.Context, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	first, err := t.first()
+	if err != nil {
+		return nil, err
+	}
+	return first.Predecessors(ctx, node)
+}
+
+// Resolve resolves the reference from the first target without failures.
+func (t *FanOutTarget) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	first, err := t.first()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return first.Resolve(ctx, reference)
+}
+
+// Tag tags the descriptor with the reference in all targets without failures.
+func (t *FanOutTarget) Tag(ctx context.Context, desc ocispec.Descriptor, reference string) error {
+	for _, i := range t.active() {
+		if err := t.targets[i].Tag(ctx, desc, reference); err != nil {
+			if err := t.fail(i, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
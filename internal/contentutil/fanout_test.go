@@ -0,0 +1,155 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+var errMockedPush = errors.New("mocked push error")
+
+// failingTarget is a target failing all pushes.
+type failingTarget struct {
+	oras.GraphTarget
+}
+
+func (t *failingTarget) Push(ctx context.Context, expected ocispec.Descriptor, r io.Reader) error {
+	// consume part of the content before failing
+	_, _ = r.Read(make([]byte, 1))
+	return errMockedPush
+}
+
+// countingReader counts the bytes read.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += n
+	return n, err
+}
+
+// existsCountingTarget counts the existence checks.
+type existsCountingTarget struct {
+	oras.GraphTarget
+	count atomic.Int32
+}
+
+func (t *existsCountingTarget) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	t.count.Add(1)
+	return t.GraphTarget.Exists(ctx, target)
+}
+
+func TestFanOutTarget_Push(t *testing.T) {
+	ctx := context.Background()
+	blob := bytes.Repeat([]byte("fan-out"), 10000)
+	desc := content.NewDescriptorFromBytes("application/octet-stream", blob)
+	dst1 := memory.New()
+	dst2 := memory.New()
+	failing := &failingTarget{GraphTarget: memory.New()}
+	fanOut := NewFanOutTarget(false, dst1, failing, dst2)
+
+	r := &countingReader{r: bytes.NewReader(blob)}
+	if err := fanOut.Push(ctx, desc, r); err != nil {
+		t.Fatalf("FanOutTarget.Push() error = %v", err)
+	}
+	if r.n != len(blob) {
+		t.Errorf("content is read %d bytes, want %d", r.n, len(blob))
+	}
+	for _, dst := range []oras.GraphTarget{dst1, dst2} {
+		if exists, err := dst.Exists(ctx, desc); err != nil || !exists {
+			t.Errorf("content is not pushed to a destination: exists = %v, err = %v", exists, err)
+		}
+	}
+	if err := fanOut.Err(0); err != nil {
+		t.Errorf("FanOutTarget.Err(0) = %v, want nil", err)
+	}
+	if err := fanOut.Err(1); !errors.Is(err, errMockedPush) {
+		t.Errorf("FanOutTarget.Err(1) = %v, want %v", err, errMockedPush)
+	}
+
+	// the failed target is excluded afterwards
+	if err := fanOut.Tag(ctx, desc, "latest"); err != nil {
+		t.Fatalf("FanOutTarget.Tag() error = %v", err)
+	}
+	if _, err := dst2.Resolve(ctx, "latest"); err != nil {
+		t.Errorf("content is not tagged: %v", err)
+	}
+	if exists, err := fanOut.Exists(ctx, desc); err != nil || !exists {
+		t.Errorf("FanOutTarget.Exists() = %v, %v, want true", exists, err)
+	}
+}
+
+func TestFanOutTarget_Push_failFast(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("fail fast")
+	desc := content.NewDescriptorFromBytes("application/octet-stream", blob)
+	fanOut := NewFanOutTarget(true, memory.New(), &failingTarget{GraphTarget: memory.New()})
+	if err := fanOut.Push(ctx, desc, bytes.NewReader(blob)); !errors.Is(err, errMockedPush) {
+		t.Errorf("FanOutTarget.Push() error = %v, want %v", err, errMockedPush)
+	}
+}
+
+func TestFanOutTarget_Push_allFailed(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("all failed")
+	desc := content.NewDescriptorFromBytes("application/octet-stream", blob)
+	fanOut := NewFanOutTarget(false, &failingTarget{GraphTarget: memory.New()}, &failingTarget{GraphTarget: memory.New()})
+	if err := fanOut.Push(ctx, desc, bytes.NewReader(blob)); !errors.Is(err, errAllTargetsFailed) {
+		t.Errorf("FanOutTarget.Push() error = %v, want %v", err, errAllTargetsFailed)
+	}
+	if _, err := fanOut.Fetch(ctx, desc); !errors.Is(err, errAllTargetsFailed) {
+		t.Errorf("FanOutTarget.Fetch() error = %v, want %v", err, errAllTargetsFailed)
+	}
+}
+
+func TestFanOutTarget_Push_skipExisting(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("existing")
+	desc := content.NewDescriptorFromBytes("application/octet-stream", blob)
+	existing := &existsCountingTarget{GraphTarget: memory.New()}
+	if err := existing.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	missing := &existsCountingTarget{GraphTarget: memory.New()}
+	fanOut := NewFanOutTarget(false, existing, missing)
+	if exists, err := fanOut.Exists(ctx, desc); err != nil || exists {
+		t.Fatalf("FanOutTarget.Exists() = %v, %v, want false", exists, err)
+	}
+	if err := fanOut.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatalf("FanOutTarget.Push() error = %v", err)
+	}
+	if exists, err := missing.GraphTarget.Exists(ctx, desc); err != nil || !exists {
+		t.Errorf("content is not pushed to the missing target: %v, %v", exists, err)
+	}
+	for i, target := range []*existsCountingTarget{existing, missing} {
+		if got := target.count.Load(); got != 1 {
+			t.Errorf("existence of target %d is checked %d times, want 1", i, got)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+type layeredTarget struct {
+	oras.ReadOnlyGraphTarget
+	lower []content.ReadOnlyStorage
+}
+
+// NewLayeredTarget returns a ReadOnlyGraphTarget that resolves references,
+// lists tags and finds predecessors from top, while content missing in top is
+// fetched from the lower storages in order.
+func NewLayeredTarget(top oras.ReadOnlyGraphTarget, lower ...content.ReadOnlyStorage) oras.ReadOnlyGraphTarget {
+	return &layeredTarget{
+		ReadOnlyGraphTarget: top,
+		lower:               lower,
+	}
+}
+
+// Fetch fetches the content from top, or from the first lower storage having
+// the content.
+func (t *layeredTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := t.ReadOnlyGraphTarget.Fetch(ctx, target)
+	if err == nil || !errors.Is(err, errdef.ErrNotFound) {
+		return rc, err
+	}
+	for _, s := range t.lower {
+		rc, err = s.Fetch(ctx, target)
+		if err == nil || !errors.Is(err, errdef.ErrNotFound) {
+			return rc, err
+		}
+	}
+	return nil, err
+}
+
+// Exists returns true if the content exists in top or in any lower storage.
+func (t *layeredTarget) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	exists, err := t.ReadOnlyGraphTarget.Exists(ctx, target)
+	if err != nil || exists {
+		return exists, err
+	}
+	for _, s := range t.lower {
+		if exists, err := s.Exists(ctx, target); err != nil || exists {
+			return exists, err
+		}
+	}
+	return false, nil
+}
+
+// Tags lists the tags of top.
+func (t *layeredTarget) Tags(ctx context.Context, last string, fn func(tags []string) error) error {
+	tagLister, ok := t.ReadOnlyGraphTarget.(registry.TagLister)
+	if !ok {
+		return errdef.ErrUnsupported
+	}
+	return tagLister.Tags(ctx, last, fn)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+func TestLayeredTarget(t *testing.T) {
+	ctx := context.Background()
+	push := func(s content.Storage, blob []byte) ocispec.Descriptor {
+		t.Helper()
+		desc := content.NewDescriptorFromBytes("application/octet-stream", blob)
+		if err := s.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	// only the top one is a tag lister
+	top, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	middle, bottom := memory.New(), memory.New()
+	inTop := push(top, []byte("top"))
+	inMiddle := push(middle, []byte("middle"))
+	inBottom := push(bottom, []byte("bottom"))
+	missing := content.NewDescriptorFromBytes("application/octet-stream", []byte("missing"))
+	if err := top.Tag(ctx, inTop, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	target := NewLayeredTarget(top, middle, bottom)
+	for _, tt := range []struct {
+		desc ocispec.Descriptor
+		want []byte
+	}{
+		{inTop, []byte("top")},
+		{inMiddle, []byte("middle")},
+		{inBottom, []byte("bottom")},
+	} {
+		got, err := content.FetchAll(ctx, target, tt.desc)
+		if err != nil {
+			t.Fatalf("Fetch(%s) error = %v", tt.desc.Digest, err)
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("Fetch(%s) = %s, want %s", tt.desc.Digest, got, tt.want)
+		}
+		if exists, err := target.Exists(ctx, tt.desc); err != nil || !exists {
+			t.Errorf("Exists(%s) = %v, %v, want true", tt.desc.Digest, exists, err)
+		}
+	}
+	if _, err := target.Fetch(ctx, missing); !errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("Fetch() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+	if exists, err := target.Exists(ctx, missing); err != nil || exists {
+		t.Errorf("Exists() = %v, %v, want false", exists, err)
+	}
+
+	var tags []string
+	if err := target.(registry.TagLister).Tags(ctx, "", func(got []string) error {
+		tags = append(tags, got...)
+		return nil
+	}); err != nil {
+		t.Fatalf("Tags() error = %v", err)
+	}
+	if !reflect.DeepEqual(tags, []string{"v1"}) {
+		t.Errorf("Tags() = %v, want [v1]", tags)
+	}
+}
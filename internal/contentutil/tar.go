@@ -0,0 +1,126 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"context"
+	"io"
+	"io/fs"
+
+	"oras.land/oras-go/v2/content/oci"
+	orasio "oras.land/oras/internal/io"
+)
+
+// NewOCIStoreFromTar creates a read-only OCI store from the OCI layout tar
+// archive at path, which is transparently decompressed if compressed. If path
+// is a volume of a split archive, all the volumes are verified and read.
+//
+// The returned closer releases the files backing the store, and should be
+// called once the store is no longer used.
+func NewOCIStoreFromTar(ctx context.Context, path string) (*oci.ReadOnlyStore, io.Closer, error) {
+	if base, ok := orasio.VolumeBase(path); ok {
+		return newOCIStoreFromVolumes(ctx, base)
+	}
+	compression, err := orasio.DetectCompression(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if compression == orasio.CompressionNone {
+		// the archive is opened on each read
+		store, err := oci.NewFromTar(ctx, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, NopCloser{}, nil
+	}
+	tfs, err := orasio.OpenTarFS(path, compression)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newOCIStoreFromTarFS(ctx, tfs)
+}
+
+// newOCIStoreFromVolumes creates a read-only OCI store from the OCI layout tar
+// archive split into the volume set at base.
+func newOCIStoreFromVolumes(ctx context.Context, base string) (*oci.ReadOnlyStore, io.Closer, error) {
+	volumes, err := orasio.OpenVolumes(base)
+	if err != nil {
+		return nil, nil, err
+	}
+	compression, err := orasio.DetectReaderCompression(volumes)
+	if err != nil {
+		_ = volumes.Close()
+		return nil, nil, err
+	}
+	tfs, err := orasio.NewTarFS(volumes, volumes.Size(), compression)
+	if err != nil {
+		_ = volumes.Close()
+		return nil, nil, err
+	}
+	return newOCIStoreFromTarFS(ctx, tfs)
+}
+
+// newOCIStoreFromTarFS creates a read-only OCI store from tfs, which is closed
+// if the store cannot be created.
+func newOCIStoreFromTarFS(ctx context.Context, tfs *orasio.TarFS) (*oci.ReadOnlyStore, io.Closer, error) {
+	store, err := oci.NewFromFS(ctx, tfs)
+	if err != nil {
+		_ = tfs.Close()
+		return nil, nil, err
+	}
+	return store, tfs, nil
+}
+
+// NopCloser is an io.Closer for content not backed by open files.
+type NopCloser struct{}
+
+// Close does nothing.
+func (NopCloser) Close() error {
+	return nil
+}
+
+// ReadFileFromTar reads the named file from the OCI layout tar archive at path,
+// which is transparently decompressed if compressed. If path is a volume of a
+// split archive, all the volumes are verified and read.
+func ReadFileFromTar(path, name string) ([]byte, error) {
+	var tfs *orasio.TarFS
+	if base, ok := orasio.VolumeBase(path); ok {
+		volumes, err := orasio.OpenVolumes(base)
+		if err != nil {
+			return nil, err
+		}
+		compression, err := orasio.DetectReaderCompression(volumes)
+		if err != nil {
+			_ = volumes.Close()
+			return nil, err
+		}
+		if tfs, err = orasio.NewTarFS(volumes, volumes.Size(), compression); err != nil {
+			return nil, err
+		}
+	} else {
+		compression, err := orasio.DetectCompression(path)
+		if err != nil {
+			return nil, err
+		}
+		if tfs, err = orasio.OpenTarFS(path, compression); err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		_ = tfs.Close()
+	}()
+	return fs.ReadFile(tfs, name)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	orasio "oras.land/oras/internal/io"
+)
+
+func TestNewOCIStoreFromTar(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, compression := range []orasio.Compression{orasio.CompressionNone, orasio.CompressionGzip, orasio.CompressionZstd} {
+		t.Run(string(compression), func(t *testing.T) {
+			var buf bytes.Buffer
+			cw, err := orasio.NewCompressWriter(&buf, compression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tw := NewTarWriter(cw)
+			if err := oras.CopyGraph(ctx, src, tw, root, oras.DefaultCopyGraphOptions); err != nil {
+				t.Fatal(err)
+			}
+			if err := tw.Tag(ctx, root, "v1"); err != nil {
+				t.Fatal(err)
+			}
+			if err := tw.Close(nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := cw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(t.TempDir(), "layout.tar")
+			if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			store, closer, err := NewOCIStoreFromTar(ctx, path)
+			if err != nil {
+				t.Fatalf("NewOCIStoreFromTar() error = %v", err)
+			}
+			if got, err := store.Resolve(ctx, "v1"); err != nil || got.Digest != root.Digest {
+				t.Errorf("Resolve() = %v, %v, want %v", got, err, root)
+			}
+			if err := closer.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if compression != orasio.CompressionNone {
+				// the decompressed archive is released
+				if _, err := content.FetchAll(ctx, store, root); err == nil {
+					t.Error("FetchAll() after Close() error = nil, want error")
+				}
+			}
+		})
+	}
+
+	t.Run("invalid archive", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "layout.tar.gz")
+		if err := os.WriteFile(path, []byte{0x1f, 0x8b, 0x08, 0x00}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := NewOCIStoreFromTar(ctx, path); err == nil {
+			t.Error("NewOCIStoreFromTar() error = nil, want error")
+		}
+	})
+}
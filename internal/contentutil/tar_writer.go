@@ -0,0 +1,276 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/internal/descriptor"
+)
+
+// TarWriter is a write-once oras.GraphTarget streaming the pushed content
+// into a tar archive as an OCI image layout. Blobs are written to the archive
+// as they are pushed, while manifests are also kept in memory so that they can
+// be fetched and the graph can be walked. The index.json and oci-layout files
+// are written when the TarWriter is closed.
+//
+// Pushes are serialized as the entries of a tar archive are sequential. Blobs
+// are streamed into the archive and verified as they are written, so a
+// corrupted blob fails the whole archive.
+type TarWriter struct {
+	lock      sync.Mutex
+	tw        *tar.Writer
+	modTime   time.Time
+	manifests *memory.Store
+	written   map[digest.Digest]ocispec.Descriptor
+	// order is the push order of the manifests
+	order []digest.Digest
+	tags  map[string]ocispec.Descriptor
+	// tagOrder is the order of the tags
+	tagOrder []string
+	closed   bool
+	// err is the error failing the archive, which cannot be written anymore.
+	err error
+}
+
+// NewTarWriter creates a TarWriter writing the tar archive to w.
+func NewTarWriter(w io.Writer) *TarWriter {
+	return &TarWriter{
+		tw:        tar.NewWriter(w),
+		modTime:   time.Now(),
+		manifests: memory.New(),
+		written:   make(map[digest.Digest]ocispec.Descriptor),
+		tags:      make(map[string]ocispec.Descriptor),
+	}
+}
+
+// Push writes the content matching the expected descriptor to the archive.
+func (t *TarWriter) Push(ctx context.Context, expected ocispec.Descriptor, r io.Reader) error {
+	if descriptor.IsManifest(expected) {
+		// manifests are small enough to be verified before being written
+		b, err := content.ReadAll(r, expected)
+		if err != nil {
+			return err
+		}
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		if err := t.checkWritable(expected); err != nil {
+			return err
+		}
+		if err := t.writeFile(blobPath(expected.Digest), expected.Size, bytes.NewReader(b)); err != nil {
+			return err
+		}
+		if err := t.manifests.Push(ctx, expected, bytes.NewReader(b)); err != nil {
+			return err
+		}
+		t.written[expected.Digest] = expected
+		t.order = append(t.order, expected.Digest)
+		return nil
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if err := t.checkWritable(expected); err != nil {
+		return err
+	}
+	vr := content.NewVerifyReader(r, expected)
+	if err := t.writeFile(blobPath(expected.Digest), expected.Size, vr); err != nil {
+		return err
+	}
+	// the entry has been written, a corrupted blob fails the whole archive
+	if err := vr.Verify(); err != nil {
+		t.err = fmt.Errorf("tar archive is corrupted by %s: %w", expected.Digest, err)
+		return err
+	}
+	t.written[expected.Digest] = expected
+	return nil
+}
+
+// checkWritable checks if the content can be written.
+func (t *TarWriter) checkWritable(expected ocispec.Descriptor) error {
+	if t.closed {
+		return fmt.Errorf("tar archive is closed")
+	}
+	if t.err != nil {
+		return t.err
+	}
+	if _, ok := t.written[expected.Digest]; ok {
+		return fmt.Errorf("%s: %s: %w", expected.Digest, expected.MediaType, errdef.ErrAlreadyExists)
+	}
+	return nil
+}
+
+// Exists returns true if the content has been written to the archive.
+func (t *TarWriter) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	_, ok := t.written[target.Digest]
+	return ok, nil
+}
+
+// Fetch fetches the manifest identified by target. Blobs cannot be fetched as
+// they are only written to the archive.
+func (t *TarWriter) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	return t.manifests.Fetch(ctx, target)
+}
+
+// Predecessors returns the manifests directly pointing to node.
+func (t *TarWriter) Predecessors(ctx context.Context, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	return t.manifests.Predecessors(ctx, node)
+}
+
+// Resolve resolves a tag or a digest of a written manifest.
+func (t *TarWriter) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if desc, ok := t.tags[reference]; ok {
+		return desc, nil
+	}
+	if dgst, err := digest.Parse(reference); err == nil {
+		if desc, ok := t.written[dgst]; ok && descriptor.IsManifest(desc) {
+			return desc, nil
+		}
+	}
+	return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+}
+
+// Tag tags a written descriptor with a reference, which is recorded as the
+// ref name annotation in index.json.
+func (t *TarWriter) Tag(_ context.Context, desc ocispec.Descriptor, reference string) error {
+	if reference == "" {
+		return errdef.ErrMissingReference
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.written[desc.Digest]; !ok {
+		return fmt.Errorf("%s: %s: %w", desc.Digest, desc.MediaType, errdef.ErrNotFound)
+	}
+	if _, ok := t.tags[reference]; !ok {
+		t.tagOrder = append(t.tagOrder, reference)
+	}
+	t.tags[reference] = desc
+	return nil
+}
+
+// WriteFile writes a regular file with data to the archive, which is placed
+// next to index.json in the OCI image layout.
+func (t *TarWriter) WriteFile(name string, data []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return fmt.Errorf("tar archive is closed")
+	}
+	if t.err != nil {
+		return t.err
+	}
+	return t.writeFile(name, int64(len(data)), bytes.NewReader(data))
+}
+
+// Close writes index.json with the given annotations and oci-layout to the
+// archive, and closes the archive. It does not close the underlying writer.
+func (t *TarWriter) Close(annotations map[string]string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	if t.err != nil {
+		return t.err
+	}
+
+	// tagged manifests first, followed by untagged manifests, which is the
+	// same as the index.json saved by oci.Store
+	var manifests []ocispec.Descriptor
+	tagged := make(map[digest.Digest]bool)
+	for _, ref := range t.tagOrder {
+		desc := t.tags[ref]
+		desc.Annotations = maps.Clone(desc.Annotations)
+		if desc.Annotations == nil {
+			desc.Annotations = make(map[string]string)
+		}
+		desc.Annotations[ocispec.AnnotationRefName] = ref
+		manifests = append(manifests, desc)
+		tagged[desc.Digest] = true
+	}
+	for _, dgst := range t.order {
+		if !tagged[dgst] {
+			manifests = append(manifests, t.written[dgst])
+		}
+	}
+	index := ocispec.Index{
+		Versioned: specs.Versioned{
+			SchemaVersion: 2,
+		},
+		MediaType:   ocispec.MediaTypeImageIndex,
+		Manifests:   manifests,
+		Annotations: annotations,
+	}
+	indexJSON, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index file: %w", err)
+	}
+	if err := t.writeFile(ocispec.ImageIndexFile, int64(len(indexJSON)), bytes.NewReader(indexJSON)); err != nil {
+		return err
+	}
+	layoutJSON, err := json.Marshal(ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion})
+	if err != nil {
+		return fmt.Errorf("failed to marshal OCI layout file: %w", err)
+	}
+	if err := t.writeFile(ocispec.ImageLayoutFile, int64(len(layoutJSON)), bytes.NewReader(layoutJSON)); err != nil {
+		return err
+	}
+	return t.tw.Close()
+}
+
+// writeFile writes a regular file of size read from r to the archive.
+func (t *TarWriter) writeFile(name string, size int64, r io.Reader) error {
+	if err := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  t.modTime,
+	}); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := io.CopyN(t.tw, r, size); err != nil {
+		// the entry is incomplete, which fails the whole archive
+		t.err = fmt.Errorf("failed to write %s: %w", name, err)
+		return t.err
+	}
+	return nil
+}
+
+// blobPath returns the path of a blob in an OCI image layout.
+func blobPath(dgst digest.Digest) string {
+	return path.Join(ocispec.ImageBlobsDir, dgst.Algorithm().String(), dgst.Encoded())
+}
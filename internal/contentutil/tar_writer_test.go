@@ -0,0 +1,160 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+func TestTarWriter(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	layer := content.NewDescriptorFromBytes("application/vnd.test.layer", []byte("layer"))
+	if err := src.Push(ctx, layer, bytes.NewReader([]byte("layer"))); err != nil {
+		t.Fatal(err)
+	}
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: []ocispec.Descriptor{layer}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	referrer, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{Subject: &root})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	tw := NewTarWriter(&buf)
+	if err := oras.ExtendedCopyGraph(ctx, src, tw, root, oras.DefaultExtendedCopyGraphOptions); err != nil {
+		t.Fatalf("ExtendedCopyGraph() error = %v", err)
+	}
+	if err := tw.Tag(ctx, root, "v1"); err != nil {
+		t.Fatalf("Tag() error = %v", err)
+	}
+	if err := tw.Tag(ctx, layer, ""); !errors.Is(err, errdef.ErrMissingReference) {
+		t.Errorf("Tag() error = %v, want %v", err, errdef.ErrMissingReference)
+	}
+	if err := tw.Tag(ctx, ocispec.Descriptor{Digest: "sha256:0000000000000000000000000000000000000000000000000000000000000000"}, "v2"); !errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("Tag() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+
+	// written content is visible before the archive is closed
+	if exists, err := tw.Exists(ctx, layer); err != nil || !exists {
+		t.Errorf("Exists() = %v, %v, want true", exists, err)
+	}
+	if got, err := tw.Resolve(ctx, "v1"); err != nil || got.Digest != root.Digest {
+		t.Errorf("Resolve() = %v, %v, want %v", got, err, root)
+	}
+	if got, err := tw.Resolve(ctx, referrer.Digest.String()); err != nil || got.Digest != referrer.Digest {
+		t.Errorf("Resolve() = %v, %v, want %v", got, err, referrer)
+	}
+	if _, err := tw.Resolve(ctx, layer.Digest.String()); !errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("Resolve() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+	referrers, err := registry.Referrers(ctx, tw, root, "")
+	if err != nil || len(referrers) != 1 || referrers[0].Digest != referrer.Digest {
+		t.Errorf("Referrers() = %v, %v, want [%v]", referrers, err, referrer)
+	}
+	if err := tw.Push(ctx, layer, bytes.NewReader([]byte("layer"))); !errors.Is(err, errdef.ErrAlreadyExists) {
+		t.Errorf("Push() error = %v, want %v", err, errdef.ErrAlreadyExists)
+	}
+
+	if err := tw.WriteFile("extra.json", []byte(`{"foo":"bar"}`)); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := tw.Close(map[string]string{"foo": "bar"}); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := tw.Push(ctx, content.NewDescriptorFromBytes("application/vnd.test", []byte("late")), bytes.NewReader([]byte("late"))); err == nil {
+		t.Error("Push() after Close() error = nil, want error")
+	}
+
+	// read the archive back as an OCI layout
+	path := filepath.Join(t.TempDir(), "layout.tar")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := oci.NewFromTar(ctx, path)
+	if err != nil {
+		t.Fatalf("oci.NewFromTar() error = %v", err)
+	}
+	if got, err := store.Resolve(ctx, "v1"); err != nil || got.Digest != root.Digest {
+		t.Errorf("Resolve() = %v, %v, want %v", got, err, root)
+	}
+	for _, desc := range []ocispec.Descriptor{root, layer, referrer} {
+		got, err := content.FetchAll(ctx, store, desc)
+		if err != nil {
+			t.Fatalf("FetchAll(%s) error = %v", desc.Digest, err)
+		}
+		if content.NewDescriptorFromBytes(desc.MediaType, got).Digest != desc.Digest {
+			t.Errorf("content of %s is corrupted", desc.Digest)
+		}
+	}
+	referrers, err = registry.Referrers(ctx, store, root, "")
+	if err != nil || len(referrers) != 1 {
+		t.Errorf("Referrers() in the archive = %v, %v, want 1 referrer", referrers, err)
+	}
+	if got, err := ReadFileFromTar(path, "extra.json"); err != nil || string(got) != `{"foo":"bar"}` {
+		t.Errorf("ReadFileFromTar() = %s, %v, want %s", got, err, `{"foo":"bar"}`)
+	}
+	if _, err := ReadFileFromTar(path, "missing.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFileFromTar() error = %v, want %v", err, fs.ErrNotExist)
+	}
+	if err := tw.WriteFile("late.json", nil); err == nil {
+		t.Error("WriteFile() after Close() error = nil, want error")
+	}
+}
+
+func TestTarWriter_Push_corrupted(t *testing.T) {
+	ctx := context.Background()
+	tw := NewTarWriter(&bytes.Buffer{})
+	desc := content.NewDescriptorFromBytes("application/vnd.test.layer", []byte("layer"))
+	if err := tw.Push(ctx, desc, bytes.NewReader([]byte("LAYER"))); err == nil {
+		t.Error("Push() error = nil, want error")
+	}
+	if exists, _ := tw.Exists(ctx, desc); exists {
+		t.Error("corrupted content should not exist")
+	}
+	manifest := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err := tw.Push(ctx, manifest, bytes.NewReader([]byte("[]"))); err == nil {
+		t.Error("Push() error = nil, want error")
+	}
+
+	// the corrupted entry has been written, which fails the whole archive
+	other := content.NewDescriptorFromBytes("application/vnd.test.layer", []byte("other"))
+	if err := tw.Push(ctx, other, bytes.NewReader([]byte("other"))); err == nil {
+		t.Error("Push() after a corrupted blob error = nil, want error")
+	}
+	if err := tw.WriteFile("inventory.json", nil); err == nil {
+		t.Error("WriteFile() after a corrupted blob error = nil, want error")
+	}
+	if err := tw.Close(nil); err == nil {
+		t.Error("Close() after a corrupted blob error = nil, want error")
+	}
+}
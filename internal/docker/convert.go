@@ -0,0 +1,150 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ociMediaTypes maps docker media types to their OCI equivalents.
+var ociMediaTypes = map[string]string{
+	MediaTypeManifest:     ocispec.MediaTypeImageManifest,
+	MediaTypeManifestList: ocispec.MediaTypeImageIndex,
+	MediaTypeConfig:       ocispec.MediaTypeImageConfig,
+	MediaTypeLayer:        ocispec.MediaTypeImageLayer,
+	MediaTypeLayerGzip:    ocispec.MediaTypeImageLayerGzip,
+	MediaTypeForeignLayer: "application/vnd.oci.image.layer.nondistributable.v1.tar+gzip",
+}
+
+// ConvertMediaType returns the OCI equivalent of a docker media type. Other
+// media types are returned as is.
+func ConvertMediaType(mediaType string) string {
+	if converted, ok := ociMediaTypes[mediaType]; ok {
+		return converted
+	}
+	return mediaType
+}
+
+// ConvertManifest converts the content of a docker image manifest into an OCI
+// image manifest. Media types of the config and layers are converted, while
+// their digests stay the same since blob content is not changed.
+func ConvertManifest(manifest []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(manifest, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if err := setField(fields, "mediaType", ocispec.MediaTypeImageManifest); err != nil {
+		return nil, err
+	}
+	convert := func(desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+		desc.MediaType = ConvertMediaType(desc.MediaType)
+		return desc, nil
+	}
+	if config, ok := fields["config"]; ok {
+		converted, err := convertDescriptor(config, convert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert config: %w", err)
+		}
+		fields["config"] = converted
+	}
+	if err := convertDescriptors(fields, "layers", convert); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// ConvertIndex converts the content of a docker manifest list or an OCI image
+// index into an OCI image index. Each child manifest is replaced with the
+// descriptor returned by convert.
+func ConvertIndex(index []byte, convert func(ocispec.Descriptor) (ocispec.Descriptor, error)) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(index, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse index: %w", err)
+	}
+	if err := setField(fields, "mediaType", ocispec.MediaTypeImageIndex); err != nil {
+		return nil, err
+	}
+	if err := convertDescriptors(fields, "manifests", convert); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// convertDescriptors converts the list of descriptors in fields[key].
+func convertDescriptors(fields map[string]json.RawMessage, key string, convert func(ocispec.Descriptor) (ocispec.Descriptor, error)) error {
+	raw, ok := fields[key]
+	if !ok {
+		return nil
+	}
+	var descs []json.RawMessage
+	if err := json.Unmarshal(raw, &descs); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", key, err)
+	}
+	for i, desc := range descs {
+		converted, err := convertDescriptor(desc, convert)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s: %w", key, err)
+		}
+		descs[i] = converted
+	}
+	converted, err := json.Marshal(descs)
+	if err != nil {
+		return err
+	}
+	fields[key] = converted
+	return nil
+}
+
+// convertDescriptor replaces the media type, digest and size of a raw
+// descriptor with the ones returned by convert. Other fields, such as
+// platforms and annotations, are kept as is.
+func convertDescriptor(raw json.RawMessage, convert func(ocispec.Descriptor) (ocispec.Descriptor, error)) (json.RawMessage, error) {
+	var desc ocispec.Descriptor
+	if err := json.Unmarshal(raw, &desc); err != nil {
+		return nil, err
+	}
+	converted, err := convert(desc)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	if err := setField(fields, "mediaType", converted.MediaType); err != nil {
+		return nil, err
+	}
+	if err := setField(fields, "digest", converted.Digest); err != nil {
+		return nil, err
+	}
+	if err := setField(fields, "size", converted.Size); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// setField sets fields[key] to the JSON encoding of value.
+func setField(fields map[string]json.RawMessage, key string, value any) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fields[key] = encoded
+	return nil
+}
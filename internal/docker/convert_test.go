@@ -0,0 +1,116 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestConvertMediaType(t *testing.T) {
+	tests := map[string]string{
+		MediaTypeManifest:              ocispec.MediaTypeImageManifest,
+		MediaTypeManifestList:          ocispec.MediaTypeImageIndex,
+		MediaTypeConfig:                ocispec.MediaTypeImageConfig,
+		MediaTypeLayerGzip:             ocispec.MediaTypeImageLayerGzip,
+		MediaTypeLayer:                 ocispec.MediaTypeImageLayer,
+		"application/vnd.unknown.type": "application/vnd.unknown.type",
+	}
+	for mediaType, want := range tests {
+		if got := ConvertMediaType(mediaType); got != want {
+			t.Errorf("ConvertMediaType(%q) = %q, want %q", mediaType, got, want)
+		}
+	}
+}
+
+func TestConvertManifest(t *testing.T) {
+	configDigest := digest.FromString("config")
+	layerDigest := digest.FromString("layer")
+	manifest := `{"schemaVersion":2,"mediaType":"` + MediaTypeManifest + `",` +
+		`"config":{"mediaType":"` + MediaTypeConfig + `","digest":"` + configDigest.String() + `","size":6},` +
+		`"layers":[{"mediaType":"` + MediaTypeLayerGzip + `","digest":"` + layerDigest.String() + `","size":5,"urls":["https://example.com/layer"]}]}`
+
+	converted, err := ConvertManifest([]byte(manifest))
+	if err != nil {
+		t.Fatalf("ConvertManifest() error = %v", err)
+	}
+	var got ocispec.Manifest
+	if err := json.Unmarshal(converted, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.SchemaVersion != 2 || got.MediaType != ocispec.MediaTypeImageManifest {
+		t.Errorf("unexpected manifest header: schemaVersion = %d, mediaType = %q", got.SchemaVersion, got.MediaType)
+	}
+	if got.Config.MediaType != ocispec.MediaTypeImageConfig || got.Config.Digest != configDigest || got.Config.Size != 6 {
+		t.Errorf("unexpected config: %+v", got.Config)
+	}
+	if len(got.Layers) != 1 {
+		t.Fatalf("got %d layers, want 1", len(got.Layers))
+	}
+	layer := got.Layers[0]
+	if layer.MediaType != ocispec.MediaTypeImageLayerGzip || layer.Digest != layerDigest || layer.Size != 5 {
+		t.Errorf("unexpected layer: %+v", layer)
+	}
+	if len(layer.URLs) != 1 {
+		t.Errorf("urls of the layer are not kept: %+v", layer)
+	}
+}
+
+func TestConvertIndex(t *testing.T) {
+	oldDigest := digest.FromString("old")
+	newDigest := digest.FromString("new")
+	index := `{"schemaVersion":2,"mediaType":"` + MediaTypeManifestList + `","manifests":[` +
+		`{"mediaType":"` + MediaTypeManifest + `","digest":"` + oldDigest.String() + `","size":3,"platform":{"architecture":"amd64","os":"linux"}}]}`
+
+	converted, err := ConvertIndex([]byte(index), func(desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+		if desc.Digest != oldDigest {
+			t.Errorf("unexpected child manifest %s", desc.Digest)
+		}
+		return ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: newDigest, Size: 4}, nil
+	})
+	if err != nil {
+		t.Fatalf("ConvertIndex() error = %v", err)
+	}
+	var got ocispec.Index
+	if err := json.Unmarshal(converted, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.MediaType != ocispec.MediaTypeImageIndex {
+		t.Errorf("mediaType = %q, want %q", got.MediaType, ocispec.MediaTypeImageIndex)
+	}
+	if len(got.Manifests) != 1 {
+		t.Fatalf("got %d manifests, want 1", len(got.Manifests))
+	}
+	child := got.Manifests[0]
+	if child.MediaType != ocispec.MediaTypeImageManifest || child.Digest != newDigest || child.Size != 4 {
+		t.Errorf("unexpected child manifest: %+v", child)
+	}
+	if child.Platform == nil || child.Platform.OS != "linux" || child.Platform.Architecture != "amd64" {
+		t.Errorf("platform of the child manifest is not kept: %+v", child.Platform)
+	}
+}
+
+func TestConvertManifest_invalid(t *testing.T) {
+	if _, err := ConvertManifest([]byte("{")); err == nil {
+		t.Error("ConvertManifest() error = nil, want error")
+	}
+	if _, err := ConvertManifest([]byte(`{"layers":{}}`)); err == nil {
+		t.Error("ConvertManifest() error = nil, want error for invalid layers")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/internal/descriptor"
+)
+
+// ArtifactWalker walks the graph of a tagged artifact together with the
+// referrers backed up with it, which are the referrers of the artifact and, if
+// the artifact is an index, the referrers of its manifests. The referrers are
+// walked recursively.
+type ArtifactWalker struct {
+	// Storage is the storage holding the graph.
+	Storage content.ReadOnlyGraphStorage
+	// WithReferrers indicates the referrers are walked.
+	WithReferrers bool
+	// Visit is called once on each node walked. referrer is true if the node
+	// is a referrer rather than a successor. If Visit returns false, the
+	// successors and the referrers of the node are not walked.
+	Visit func(ctx context.Context, desc ocispec.Descriptor, referrer bool) (bool, error)
+	// OnSuccessorsError is called if the successors of a manifest cannot be
+	// resolved. The successors are skipped if it returns nil. If it is not set,
+	// the walk stops with the error.
+	OnSuccessorsError func(ctx context.Context, desc ocispec.Descriptor, err error) error
+}
+
+// Walk walks the graph of root in breadth-first order.
+func (w *ArtifactWalker) Walk(ctx context.Context, root ocispec.Descriptor) error {
+	type node struct {
+		desc ocispec.Descriptor
+		// withReferrers indicates the referrers of the node are walked
+		withReferrers bool
+		referrer      bool
+	}
+	visited := map[digest.Digest]bool{root.Digest: true}
+	queue := []node{{desc: root, withReferrers: w.WithReferrers}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		descend, err := w.Visit(ctx, current.desc, current.referrer)
+		if err != nil {
+			return err
+		}
+		if !descend || !descriptor.IsManifest(current.desc) {
+			continue
+		}
+
+		successors, err := content.Successors(ctx, w.Storage, current.desc)
+		if err != nil {
+			if w.OnSuccessorsError == nil {
+				return fmt.Errorf("failed to get successors of %s: %w", current.desc.Digest, err)
+			}
+			if err := w.OnSuccessorsError(ctx, current.desc, err); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, successor := range successors {
+			if visited[successor.Digest] {
+				continue
+			}
+			visited[successor.Digest] = true
+			queue = append(queue, node{
+				desc:          successor,
+				withReferrers: w.WithReferrers && current.desc.Digest == root.Digest && descriptor.IsIndex(root),
+			})
+		}
+		if !current.withReferrers {
+			continue
+		}
+		referrers, err := registry.Referrers(ctx, w.Storage, current.desc, "")
+		if err != nil {
+			return fmt.Errorf("failed to find referrers of %s: %w", current.desc.Digest, err)
+		}
+		for _, referrer := range referrers {
+			if visited[referrer.Digest] {
+				continue
+			}
+			visited[referrer.Digest] = true
+			queue = append(queue, node{desc: referrer, withReferrers: true, referrer: true})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestArtifactWalker_Walk(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	layer := content.NewDescriptorFromBytes("application/vnd.test.layer", []byte("layer"))
+	if err := store.Push(ctx, layer, bytes.NewReader([]byte("layer"))); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: []ocispec.Descriptor{layer}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexJSON, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{manifest},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, indexJSON)
+	if err := store.Push(ctx, index, bytes.NewReader(indexJSON)); err != nil {
+		t.Fatal(err)
+	}
+	sign := func(subject ocispec.Descriptor, artifactType string) ocispec.Descriptor {
+		t.Helper()
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{Subject: &subject})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	indexReferrer := sign(index, "application/vnd.test.sig")
+	manifestReferrer := sign(manifest, "application/vnd.test.sbom")
+	nestedReferrer := sign(indexReferrer, "application/vnd.test.sig.nested")
+
+	config := ocispec.DescriptorEmptyJSON
+	type visit struct {
+		digest   digest.Digest
+		referrer bool
+	}
+	walk := func(t *testing.T, walker *ArtifactWalker, root ocispec.Descriptor) []visit {
+		t.Helper()
+		var visits []visit
+		visitFunc := walker.Visit
+		walker.Visit = func(ctx context.Context, desc ocispec.Descriptor, referrer bool) (bool, error) {
+			visits = append(visits, visit{digest: desc.Digest, referrer: referrer})
+			if visitFunc != nil {
+				return visitFunc(ctx, desc, referrer)
+			}
+			return true, nil
+		}
+		if err := walker.Walk(ctx, root); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+		return visits
+	}
+
+	t.Run("with referrers", func(t *testing.T) {
+		got := walk(t, &ArtifactWalker{Storage: store, WithReferrers: true}, index)
+		want := []visit{
+			{digest: index.Digest},
+			{digest: manifest.Digest},
+			{digest: indexReferrer.Digest, referrer: true},
+			{digest: config.Digest},
+			{digest: layer.Digest},
+			{digest: manifestReferrer.Digest, referrer: true},
+			{digest: nestedReferrer.Digest, referrer: true},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Walk() visited %v, want %v", got, want)
+		}
+	})
+
+	t.Run("without referrers", func(t *testing.T) {
+		got := walk(t, &ArtifactWalker{Storage: store}, index)
+		want := []visit{{digest: index.Digest}, {digest: manifest.Digest}, {digest: config.Digest}, {digest: layer.Digest}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Walk() visited %v, want %v", got, want)
+		}
+	})
+
+	t.Run("referrers of the successors of a manifest are not walked", func(t *testing.T) {
+		got := walk(t, &ArtifactWalker{Storage: store, WithReferrers: true}, manifest)
+		want := []visit{{digest: manifest.Digest}, {digest: config.Digest}, {digest: layer.Digest}, {digest: manifestReferrer.Digest, referrer: true}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Walk() visited %v, want %v", got, want)
+		}
+	})
+
+	t.Run("skip node", func(t *testing.T) {
+		walker := &ArtifactWalker{
+			Storage:       store,
+			WithReferrers: true,
+			Visit: func(_ context.Context, desc ocispec.Descriptor, _ bool) (bool, error) {
+				return desc.Digest != manifest.Digest, nil
+			},
+		}
+		got := walk(t, walker, index)
+		want := []visit{
+			{digest: index.Digest},
+			{digest: manifest.Digest},
+			{digest: indexReferrer.Digest, referrer: true},
+			// the config shared with the referrer is still walked
+			{digest: config.Digest},
+			{digest: nestedReferrer.Digest, referrer: true},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Walk() visited %v, want %v", got, want)
+		}
+	})
+
+	t.Run("visit error", func(t *testing.T) {
+		errVisit := errors.New("visit error")
+		walker := &ArtifactWalker{
+			Storage: store,
+			Visit: func(context.Context, ocispec.Descriptor, bool) (bool, error) {
+				return false, errVisit
+			},
+		}
+		if err := walker.Walk(ctx, index); !errors.Is(err, errVisit) {
+			t.Errorf("Walk() error = %v, want %v", err, errVisit)
+		}
+	})
+
+	t.Run("successors error", func(t *testing.T) {
+		// the successors of a missing manifest cannot be resolved
+		invalid := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("missing"))
+		visit := func(context.Context, ocispec.Descriptor, bool) (bool, error) {
+			return true, nil
+		}
+		walker := &ArtifactWalker{Storage: store, Visit: visit}
+		if err := walker.Walk(ctx, invalid); err == nil {
+			t.Error("Walk() error = nil, want error")
+		}
+
+		var handled []ocispec.Descriptor
+		walker.OnSuccessorsError = func(_ context.Context, desc ocispec.Descriptor, _ error) error {
+			handled = append(handled, desc)
+			return nil
+		}
+		if err := walker.Walk(ctx, invalid); err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+		if want := []ocispec.Descriptor{invalid}; !reflect.DeepEqual(handled, want) {
+			t.Errorf("OnSuccessorsError() called with %v, want %v", handled, want)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory describes the artifacts in a backup, so that the content
+// of a backup can be audited without restoring it.
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/docker"
+	"oras.land/oras/internal/graph"
+)
+
+// FileName is the name of the inventory file in the root of an OCI image
+// layout.
+const FileName = "inventory.json"
+
+// unknownArtifactType is the key of the referrers without an artifact type.
+const unknownArtifactType = "unknown"
+
+// Inventory lists the tagged artifacts in a backup.
+type Inventory struct {
+	// Source is the repository or the namespace backed up.
+	Source string `json:"source"`
+	// Created is the time the backup is created.
+	Created time.Time `json:"created"`
+	// OrasVersion is the version of oras creating the backup.
+	OrasVersion string `json:"orasVersion"`
+	// Size is the total size in bytes of the unique content of the artifacts,
+	// including their referrers.
+	Size int64 `json:"size"`
+	// Tags are the tagged artifacts in the backup.
+	Tags []Tag `json:"tags"`
+
+	// seen records the content counted in Size.
+	seen map[digest.Digest]struct{}
+}
+
+// Tag is a tagged artifact in a backup.
+type Tag struct {
+	// Name is the reference name of the artifact in the backup.
+	Name string `json:"name"`
+	// Digest is the digest of the artifact.
+	Digest digest.Digest `json:"digest"`
+	// MediaType is the media type of the artifact.
+	MediaType string `json:"mediaType"`
+	// Platforms are the platforms of the artifact in the form of
+	// <os>/<architecture>[/<variant>], if known.
+	Platforms []string `json:"platforms,omitempty"`
+	// Referrers counts the referrers of the artifact by artifact type.
+	Referrers map[string]int `json:"referrers,omitempty"`
+}
+
+// New creates an empty inventory.
+func New(source, orasVersion string, created time.Time) *Inventory {
+	return &Inventory{
+		Source:      source,
+		Created:     created,
+		OrasVersion: orasVersion,
+		Tags:        []Tag{},
+		seen:        make(map[digest.Digest]struct{}),
+	}
+}
+
+// Parse parses an inventory file.
+func Parse(data []byte) (*Inventory, error) {
+	var inv Inventory
+	if err := json.Unmarshal(data, &inv); err != nil {
+		return nil, fmt.Errorf("failed to parse inventory: %w", err)
+	}
+	return &inv, nil
+}
+
+// Add adds the artifact tagged as name to the inventory, which is read from
+// target. If withReferrers is true, the referrers of root, as well as the
+// referrers of its manifests if root is an index, are added recursively.
+func (inv *Inventory) Add(ctx context.Context, target content.ReadOnlyGraphStorage, name string, root ocispec.Descriptor, withReferrers bool) error {
+	if inv.seen == nil {
+		inv.seen = make(map[digest.Digest]struct{})
+	}
+	tag := Tag{
+		Name:      name,
+		Digest:    root.Digest,
+		MediaType: root.MediaType,
+	}
+	platforms, err := artifactPlatforms(ctx, target, root)
+	if err != nil {
+		return err
+	}
+	tag.Platforms = platforms
+
+	walker := &graph.ArtifactWalker{
+		Storage:       target,
+		WithReferrers: withReferrers,
+		Visit: func(_ context.Context, desc ocispec.Descriptor, referrer bool) (bool, error) {
+			if _, ok := inv.seen[desc.Digest]; !ok {
+				inv.seen[desc.Digest] = struct{}{}
+				inv.Size += desc.Size
+			}
+			if referrer {
+				if tag.Referrers == nil {
+					tag.Referrers = make(map[string]int)
+				}
+				artifactType := desc.ArtifactType
+				if artifactType == "" {
+					artifactType = unknownArtifactType
+				}
+				tag.Referrers[artifactType]++
+			}
+			return true, nil
+		},
+	}
+	if err := walker.Walk(ctx, root); err != nil {
+		return err
+	}
+	inv.Tags = append(inv.Tags, tag)
+	return nil
+}
+
+// artifactPlatforms returns the platforms of the manifests of an index, or the
+// platform in the config of an image.
+func artifactPlatforms(ctx context.Context, fetcher content.Fetcher, root ocispec.Descriptor) ([]string, error) {
+	switch {
+	case descriptor.IsIndex(root):
+		manifestJSON, err := content.FetchAll(ctx, fetcher, root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", root.Digest, err)
+		}
+		var index ocispec.Index
+		if err := json.Unmarshal(manifestJSON, &index); err != nil {
+			return nil, fmt.Errorf("failed to parse index %s: %w", root.Digest, err)
+		}
+		var platforms []string
+		for _, manifest := range index.Manifests {
+			if manifest.Platform == nil || manifest.Platform.OS == "" {
+				continue
+			}
+			if platform := formatPlatform(*manifest.Platform); !slices.Contains(platforms, platform) {
+				platforms = append(platforms, platform)
+			}
+		}
+		return platforms, nil
+	case descriptor.IsImageManifest(root):
+		manifestJSON, err := content.FetchAll(ctx, fetcher, root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", root.Digest, err)
+		}
+		var manifest ocispec.Manifest
+		if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest %s: %w", root.Digest, err)
+		}
+		if docker.ConvertMediaType(manifest.Config.MediaType) != ocispec.MediaTypeImageConfig {
+			// not an image
+			return nil, nil
+		}
+		configJSON, err := content.FetchAll(ctx, fetcher, manifest.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch config %s: %w", manifest.Config.Digest, err)
+		}
+		var config ocispec.Image
+		if err := json.Unmarshal(configJSON, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", manifest.Config.Digest, err)
+		}
+		if config.OS == "" {
+			return nil, nil
+		}
+		return []string{formatPlatform(config.Platform)}, nil
+	}
+	return nil, nil
+}
+
+// formatPlatform formats platform in the form of
+// <os>/<architecture>[/<variant>].
+func formatPlatform(platform ocispec.Platform) string {
+	formatted := platform.OS + "/" + platform.Architecture
+	if platform.Variant != "" {
+		formatted += "/" + platform.Variant
+	}
+	return formatted
+}
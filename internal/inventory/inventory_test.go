@@ -0,0 +1,146 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func TestInventory_Add(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	var total int64
+	push := func(mediaType string, v any) ocispec.Descriptor {
+		t.Helper()
+		b, ok := v.([]byte)
+		if !ok {
+			var err error
+			if b, err = json.Marshal(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		desc := content.NewDescriptorFromBytes(mediaType, b)
+		if err := store.Push(ctx, desc, bytes.NewReader(b)); err != nil {
+			if errors.Is(err, errdef.ErrAlreadyExists) {
+				return desc
+			}
+			t.Fatal(err)
+		}
+		total += desc.Size
+		return desc
+	}
+	image := func(platform ocispec.Platform) ocispec.Descriptor {
+		t.Helper()
+		config := push(ocispec.MediaTypeImageConfig, ocispec.Image{Platform: platform})
+		layer := push(ocispec.MediaTypeImageLayer, []byte("layer of "+platform.Architecture))
+		desc := push(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    config,
+			Layers:    []ocispec.Descriptor{layer},
+		})
+		desc.Platform = &platform
+		return desc
+	}
+	referrer := func(artifactType string, subject ocispec.Descriptor) ocispec.Descriptor {
+		t.Helper()
+		return push(ocispec.MediaTypeImageManifest, ocispec.Manifest{
+			Versioned:    specs.Versioned{SchemaVersion: 2},
+			MediaType:    ocispec.MediaTypeImageManifest,
+			ArtifactType: artifactType,
+			Config:       push(ocispec.MediaTypeEmptyJSON, []byte("{}")),
+			Layers:       []ocispec.Descriptor{push("application/vnd.test", []byte(artifactType))},
+			Subject:      &subject,
+		})
+	}
+
+	amd64 := image(ocispec.Platform{OS: "linux", Architecture: "amd64"})
+	arm64 := image(ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"})
+	index := push(ocispec.MediaTypeImageIndex, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{amd64, arm64},
+	})
+	signature := referrer("application/vnd.test.signature", index)
+	referrer("application/vnd.test.signature", signature)
+	referrer("application/vnd.test.sbom", amd64)
+
+	created := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	inv := New("localhost:5000/hello", "1.3.0", created)
+	if err := inv.Add(ctx, store, "v1", index, true); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	// content shared with the previous tag is counted once
+	if err := inv.Add(ctx, store, "v1-amd64", amd64, false); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	wantTags := []Tag{
+		{
+			Name:      "v1",
+			Digest:    index.Digest,
+			MediaType: ocispec.MediaTypeImageIndex,
+			Platforms: []string{"linux/amd64", "linux/arm64/v8"},
+			Referrers: map[string]int{
+				"application/vnd.test.signature": 2,
+				"application/vnd.test.sbom":      1,
+			},
+		},
+		{
+			Name:      "v1-amd64",
+			Digest:    amd64.Digest,
+			MediaType: ocispec.MediaTypeImageManifest,
+			Platforms: []string{"linux/amd64"},
+		},
+	}
+	if !reflect.DeepEqual(inv.Tags, wantTags) {
+		t.Errorf("Tags = %+v, want %+v", inv.Tags, wantTags)
+	}
+	if inv.Size != total {
+		t.Errorf("Size = %d, want %d", inv.Size, total)
+	}
+
+	// round trip
+	data, err := json.Marshal(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Source != inv.Source || !parsed.Created.Equal(created) || parsed.OrasVersion != "1.3.0" || parsed.Size != inv.Size || !reflect.DeepEqual(parsed.Tags, inv.Tags) {
+		t.Errorf("Parse() = %+v, want %+v", parsed, inv)
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	if _, err := Parse([]byte("not json")); err == nil {
+		t.Error("Parse() error = nil, want error")
+	}
+}
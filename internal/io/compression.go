@@ -0,0 +1,159 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the compression algorithm of a tar archive.
+type Compression string
+
+const (
+	// CompressionNone indicates the tar archive is not compressed.
+	CompressionNone Compression = "none"
+	// CompressionGzip indicates the tar archive is compressed with gzip.
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd indicates the tar archive is compressed with zstd.
+	CompressionZstd Compression = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// tarExtensions maps the file extensions of tar archives to their compression.
+var tarExtensions = []struct {
+	ext         string
+	compression Compression
+}{
+	{".tar", CompressionNone},
+	{".tar.gz", CompressionGzip},
+	{".tgz", CompressionGzip},
+	{".tar.zst", CompressionZstd},
+	{".tar.zstd", CompressionZstd},
+}
+
+// ParseCompression parses the name of a compression algorithm.
+func ParseCompression(name string) (Compression, error) {
+	switch c := Compression(strings.ToLower(name)); c {
+	case CompressionNone, CompressionGzip, CompressionZstd:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown compression %q, supported values are %q, %q and %q", name, CompressionNone, CompressionGzip, CompressionZstd)
+}
+
+// CompressionFromExt returns the compression of a tar archive by the file
+// extension of path. It returns false if path does not have the extension of a
+// tar archive.
+func CompressionFromExt(path string) (Compression, bool) {
+	lower := strings.ToLower(path)
+	for _, te := range tarExtensions {
+		if strings.HasSuffix(lower, te.ext) {
+			return te.compression, true
+		}
+	}
+	return "", false
+}
+
+// DetectCompression detects the compression of the file at path by its magic
+// number. CompressionNone is returned if the file is not compressed by a known
+// algorithm.
+func DetectCompression(path string) (Compression, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer func() {
+		_ = fp.Close()
+	}()
+
+	compression, err := DetectReaderCompression(fp)
+	if err != nil {
+		return "", fmt.Errorf("failed to read magic number from file %q: %w", path, err)
+	}
+	return compression, nil
+}
+
+// DetectReaderCompression detects the compression of the content in r by its
+// magic number. CompressionNone is returned if the content is not compressed
+// by a known algorithm.
+func DetectReaderCompression(r io.ReaderAt) (Compression, error) {
+	magic := make([]byte, len(zstdMagic))
+	n, err := r.ReadAt(magic, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	magic = magic[:n]
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return CompressionGzip, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		return CompressionZstd, nil
+	}
+	return CompressionNone, nil
+}
+
+// NewCompressWriter returns a writer compressing the data written to w.
+// Closing the returned writer does not close w. An empty compression is
+// treated as CompressionNone.
+func NewCompressWriter(w io.Writer, compression Compression) (io.WriteCloser, error) {
+	switch compression {
+	case "", CompressionNone:
+		return nopWriteCloser{w}, nil
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w)
+	}
+	return nil, fmt.Errorf("unknown compression %q", compression)
+}
+
+// NewDecompressReader returns a reader decompressing the data read from r.
+// Closing the returned reader does not close r. An empty compression is
+// treated as CompressionNone.
+func NewDecompressReader(r io.Reader, compression Compression) (io.ReadCloser, error) {
+	switch compression {
+	case "", CompressionNone:
+		return io.NopCloser(r), nil
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	}
+	return nil, fmt.Errorf("unknown compression %q", compression)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	orasio "oras.land/oras/internal/io"
+)
+
+func TestCompressionFromExt(t *testing.T) {
+	tests := []struct {
+		path      string
+		want      orasio.Compression
+		wantIsTar bool
+	}{
+		{path: "backup.tar", want: orasio.CompressionNone, wantIsTar: true},
+		{path: "backup.TAR.GZ", want: orasio.CompressionGzip, wantIsTar: true},
+		{path: "backup.tgz", want: orasio.CompressionGzip, wantIsTar: true},
+		{path: "backup.tar.zst", want: orasio.CompressionZstd, wantIsTar: true},
+		{path: "backup.gz", wantIsTar: false},
+		{path: "backup", wantIsTar: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, isTar := orasio.CompressionFromExt(tt.path)
+			if got != tt.want || isTar != tt.wantIsTar {
+				t.Errorf("CompressionFromExt() = (%q, %v), want (%q, %v)", got, isTar, tt.want, tt.wantIsTar)
+			}
+		})
+	}
+}
+
+func TestParseCompression(t *testing.T) {
+	if got, err := orasio.ParseCompression("GZIP"); err != nil || got != orasio.CompressionGzip {
+		t.Errorf("ParseCompression() = (%q, %v), want (%q, nil)", got, err, orasio.CompressionGzip)
+	}
+	if _, err := orasio.ParseCompression("bzip2"); err == nil {
+		t.Error("ParseCompression() error = nil, want error")
+	}
+}
+
+func TestDetectCompression(t *testing.T) {
+	tmpDir := t.TempDir()
+	tests := []struct {
+		name    string
+		content []byte
+		want    orasio.Compression
+	}{
+		{name: "gzip", content: []byte{0x1f, 0x8b, 0x08, 0x00}, want: orasio.CompressionGzip},
+		{name: "zstd", content: []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}, want: orasio.CompressionZstd},
+		{name: "plain", content: []byte("plain content"), want: orasio.CompressionNone},
+		{name: "short", content: []byte{0x1f}, want: orasio.CompressionNone},
+		{name: "empty", want: orasio.CompressionNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, tt.name)
+			if err := os.WriteFile(path, tt.content, 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := orasio.DetectCompression(path)
+			if err != nil {
+				t.Fatalf("DetectCompression() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectCompression() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if _, err := orasio.DetectCompression(filepath.Join(tmpDir, "missing")); err == nil {
+		t.Error("DetectCompression() error = nil, want error")
+	}
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	data := []byte("hello world")
+	for _, compression := range []orasio.Compression{orasio.CompressionNone, orasio.CompressionGzip, orasio.CompressionZstd} {
+		t.Run(string(compression), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := orasio.NewCompressWriter(&buf, compression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.Write(data); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			r, err := orasio.NewDecompressReader(&buf, compression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("round trip = %q, want %q", got, data)
+			}
+			if err := r.Close(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+)
+
+// TarFS is a read-only file system of the regular files in a tar archive,
+// which may be compressed.
+type TarFS struct {
+	ra      io.ReaderAt
+	closer  io.Closer
+	tmpPath string
+	entries map[string]*tarEntry
+}
+
+// tarEntry is a regular file in the tar archive.
+type tarEntry struct {
+	header *tar.Header
+	offset int64
+}
+
+// OpenTarFS opens the tar archive at path compressed with compression.
+func OpenTarFS(path string, compression Compression) (*TarFS, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	fi, err := fp.Stat()
+	if err != nil {
+		_ = fp.Close()
+		return nil, fmt.Errorf("failed to stat file %q: %w", path, err)
+	}
+	tfs, err := NewTarFS(fp, fi.Size(), compression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tar archive %q: %w", path, err)
+	}
+	return tfs, nil
+}
+
+// NewTarFS creates a TarFS from the tar archive of size bytes in r compressed
+// with compression. The TarFS takes the ownership of r, which is closed when
+// the TarFS is closed if r is an io.Closer.
+//
+// A compressed archive is decompressed into a temporary file, which is unlinked
+// right away on platforms allowing removing open files so that it does not
+// outlive the process, or otherwise removed when the TarFS is closed. In this
+// case, r is closed once decompressed.
+func NewTarFS(r io.ReaderAt, size int64, compression Compression) (_ *TarFS, err error) {
+	closer, _ := r.(io.Closer)
+	tfs := &TarFS{ra: r, closer: closer}
+	defer func() {
+		if err != nil {
+			_ = tfs.Close()
+		}
+	}()
+
+	if compression != CompressionNone {
+		rc, err := NewDecompressReader(io.NewSectionReader(r, 0, size), compression)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = rc.Close()
+		}()
+		tmp, err := os.CreateTemp("", "oras-tar-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary file: %w", err)
+		}
+		if closer != nil {
+			defer func() {
+				_ = closer.Close()
+			}()
+		}
+		tfs.ra, tfs.closer = tmp, tmp
+		if err := os.Remove(tmp.Name()); err != nil {
+			tfs.tmpPath = tmp.Name()
+		}
+		if size, err = io.Copy(tmp, rc); err != nil {
+			return nil, fmt.Errorf("failed to decompress: %w", err)
+		}
+	}
+
+	if err := tfs.index(size); err != nil {
+		return nil, err
+	}
+	return tfs, nil
+}
+
+// index records the offsets of the regular files in the tar archive.
+func (tfs *TarFS) index(size int64) error {
+	cr := &countReader{r: io.NewSectionReader(tfs.ra, 0, size)}
+	tr := tar.NewReader(cr)
+	tfs.entries = make(map[string]*tarEntry)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		tfs.entries[path.Clean(header.Name)] = &tarEntry{
+			header: header,
+			offset: cr.n,
+		}
+	}
+}
+
+// Open opens the named regular file.
+func (tfs *TarFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	entry, ok := tfs.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &tarFile{
+		SectionReader: io.NewSectionReader(tfs.ra, entry.offset, entry.header.Size),
+		info:          entry.header.FileInfo(),
+	}, nil
+}
+
+// Close closes the tar archive and removes the decompressed temporary file if
+// any.
+func (tfs *TarFS) Close() error {
+	var err error
+	if tfs.closer != nil {
+		err = tfs.closer.Close()
+	}
+	if tfs.tmpPath != "" {
+		if removeErr := os.Remove(tfs.tmpPath); removeErr != nil && err == nil {
+			err = removeErr
+		}
+	}
+	return err
+}
+
+// tarFile is a regular file in TarFS.
+type tarFile struct {
+	*io.SectionReader
+	info fs.FileInfo
+}
+
+// Stat returns the file info of the file.
+func (f *tarFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+// Close is a no-op as the file shares the underlying tar archive.
+func (f *tarFile) Close() error {
+	return nil
+}
+
+// countReader counts the bytes read from r.
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
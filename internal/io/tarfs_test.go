@@ -0,0 +1,117 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	orasio "oras.land/oras/internal/io"
+)
+
+func TestOpenTarFS(t *testing.T) {
+	srcDir := t.TempDir()
+	files := map[string]string{
+		"oci-layout":           `{"imageLayoutVersion":"1.0.0"}`,
+		"blobs/sha256/abc":     "blob content",
+		"blobs/sha256/def/ghi": "nested content",
+	}
+	for name, content := range files {
+		path := filepath.Join(srcDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, compression := range []orasio.Compression{orasio.CompressionNone, orasio.CompressionGzip} {
+		t.Run(string(compression), func(t *testing.T) {
+			tarPath := filepath.Join(t.TempDir(), "layout.tar")
+			fp, err := os.Create(tarPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w, err := orasio.NewCompressWriter(fp, compression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := orasio.TarDirectory(w, srcDir); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := fp.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			tfs, err := orasio.OpenTarFS(tarPath, compression)
+			if err != nil {
+				t.Fatalf("OpenTarFS() error = %v", err)
+			}
+			defer func() {
+				if err := tfs.Close(); err != nil {
+					t.Errorf("Close() error = %v", err)
+				}
+			}()
+			for name, want := range files {
+				f, err := tfs.Open(name)
+				if err != nil {
+					t.Fatalf("Open(%q) error = %v", name, err)
+				}
+				got, err := io.ReadAll(f)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(got) != want {
+					t.Errorf("Open(%q) content = %q, want %q", name, got, want)
+				}
+				fi, err := f.Stat()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if fi.Size() != int64(len(want)) {
+					t.Errorf("Stat(%q).Size() = %d, want %d", name, fi.Size(), len(want))
+				}
+			}
+			if _, err := tfs.Open("missing"); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Open() error = %v, want %v", err, fs.ErrNotExist)
+			}
+			if _, err := tfs.Open("../oci-layout"); !errors.Is(err, fs.ErrInvalid) {
+				t.Errorf("Open() error = %v, want %v", err, fs.ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestOpenTarFS_InvalidArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.tar.gz")
+	if err := os.WriteFile(path, []byte("not gzip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := orasio.OpenTarFS(path, orasio.CompressionGzip); err == nil {
+		t.Error("OpenTarFS() error = nil, want error")
+	}
+	if _, err := orasio.OpenTarFS(filepath.Join(t.TempDir(), "missing"), orasio.CompressionNone); err == nil {
+		t.Error("OpenTarFS() error = nil, want error")
+	}
+}
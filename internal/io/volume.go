@@ -0,0 +1,360 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// volumeManifestSuffix is the suffix of the volume manifest file appended to
+// the base path of a volume set.
+const volumeManifestSuffix = ".volumes.json"
+
+// volumeSuffixRegexp matches the suffix of a volume file, e.g. ".001".
+var volumeSuffixRegexp = regexp.MustCompile(`\.\d{3,}$`)
+
+// ErrInvalidVolume is returned when a volume of a volume set is missing or
+// corrupted.
+var ErrInvalidVolume = errors.New("invalid volume")
+
+// VolumeManifest lists the volumes of a volume set.
+type VolumeManifest struct {
+	// Volumes are the volumes in order.
+	Volumes []Volume `json:"volumes"`
+}
+
+// Volume is a file of a volume set.
+type Volume struct {
+	// Name is the file name of the volume.
+	Name string `json:"name"`
+	// Size is the size of the volume in bytes.
+	Size int64 `json:"size"`
+	// Digest is the checksum of the volume.
+	Digest digest.Digest `json:"digest"`
+}
+
+// Size returns the total size of the volumes.
+func (m *VolumeManifest) Size() int64 {
+	var size int64
+	for _, v := range m.Volumes {
+		size += v.Size
+	}
+	return size
+}
+
+// ReadVolumeManifest reads the volume manifest of the volume set at base.
+func ReadVolumeManifest(base string) (*VolumeManifest, error) {
+	manifestPath := VolumeManifestPath(base)
+	manifestJSON, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read volume manifest: %w", err)
+	}
+	var manifest VolumeManifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse volume manifest %s: %w", manifestPath, err)
+	}
+	if len(manifest.Volumes) == 0 {
+		return nil, fmt.Errorf("%w: no volumes listed in %s", ErrInvalidVolume, manifestPath)
+	}
+	return &manifest, nil
+}
+
+// VolumePath returns the path of the volume at index, starting from 0, of the
+// volume set at base, e.g. "backup.tar.001".
+func VolumePath(base string, index int) string {
+	return fmt.Sprintf("%s.%03d", base, index+1)
+}
+
+// VolumeManifestPath returns the path of the volume manifest of the volume set
+// at base, e.g. "backup.tar.volumes.json".
+func VolumeManifestPath(base string) string {
+	return base + volumeManifestSuffix
+}
+
+// VolumeBase returns the base path of the volume set that the file at path
+// belongs to, if path is a volume or a volume manifest and the volume
+// manifest exists.
+func VolumeBase(path string) (string, bool) {
+	base, ok := strings.CutSuffix(path, volumeManifestSuffix)
+	if !ok {
+		loc := volumeSuffixRegexp.FindStringIndex(path)
+		if loc == nil {
+			return "", false
+		}
+		base = path[:loc[0]]
+	}
+	if _, err := os.Stat(VolumeManifestPath(base)); err != nil {
+		return "", false
+	}
+	return base, true
+}
+
+// VolumeWriter splits the data written into volumes of a fixed size. The
+// volume manifest listing the volumes with their checksums is written when the
+// VolumeWriter is closed.
+type VolumeWriter struct {
+	base       string
+	volumeSize int64
+	file       *os.File
+	written    int64
+	digester   digest.Digester
+	manifest   VolumeManifest
+}
+
+// NewVolumeWriter creates a VolumeWriter writing volumes of volumeSize bytes
+// at base.
+func NewVolumeWriter(base string, volumeSize int64) (*VolumeWriter, error) {
+	if volumeSize <= 0 {
+		return nil, fmt.Errorf("invalid volume size %d", volumeSize)
+	}
+	return &VolumeWriter{
+		base:       base,
+		volumeSize: volumeSize,
+	}, nil
+}
+
+// Write writes p across the volumes.
+func (w *VolumeWriter) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		if w.file == nil {
+			if err := w.nextVolume(); err != nil {
+				return n, err
+			}
+		}
+		chunk := p
+		if left := w.volumeSize - w.written; int64(len(chunk)) > left {
+			chunk = chunk[:left]
+		}
+		written, err := w.file.Write(chunk)
+		w.digester.Hash().Write(chunk[:written])
+		w.written += int64(written)
+		n += written
+		if err != nil {
+			return n, err
+		}
+		p = p[written:]
+		if w.written == w.volumeSize {
+			if err := w.closeVolume(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// nextVolume creates the next volume.
+func (w *VolumeWriter) nextVolume() error {
+	path := VolumePath(w.base, len(w.manifest.Volumes))
+	fp, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create volume %s: %w", path, err)
+	}
+	w.file = fp
+	w.written = 0
+	w.digester = digest.Canonical.Digester()
+	return nil
+}
+
+// closeVolume closes the current volume and records it in the manifest.
+func (w *VolumeWriter) closeVolume() error {
+	path := w.file.Name()
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close volume %s: %w", path, err)
+	}
+	w.file = nil
+	w.manifest.Volumes = append(w.manifest.Volumes, Volume{
+		Name:   filepath.Base(path),
+		Size:   w.written,
+		Digest: w.digester.Digest(),
+	})
+	return nil
+}
+
+// Close closes the last volume and writes the volume manifest.
+func (w *VolumeWriter) Close() error {
+	if w.file == nil && len(w.manifest.Volumes) == 0 {
+		// always write at least one volume
+		if err := w.nextVolume(); err != nil {
+			return err
+		}
+	}
+	if w.file != nil {
+		if err := w.closeVolume(); err != nil {
+			return err
+		}
+	}
+	manifestJSON, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(VolumeManifestPath(w.base), manifestJSON, 0666); err != nil {
+		return fmt.Errorf("failed to write volume manifest: %w", err)
+	}
+	return nil
+}
+
+// Volumes returns the paths of the volumes written so far.
+func (w *VolumeWriter) Volumes() []string {
+	dir := filepath.Dir(w.base)
+	paths := make([]string, 0, len(w.manifest.Volumes)+1)
+	for _, v := range w.manifest.Volumes {
+		paths = append(paths, filepath.Join(dir, v.Name))
+	}
+	if w.file != nil {
+		paths = append(paths, w.file.Name())
+	}
+	return paths
+}
+
+// Remove closes and removes the volumes written so far, used to clean up an
+// incomplete volume set.
+func (w *VolumeWriter) Remove() error {
+	if w.file != nil {
+		_ = w.file.Close()
+	}
+	var errs []error
+	for _, path := range append(w.Volumes(), VolumeManifestPath(w.base)) {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	w.file = nil
+	return errors.Join(errs...)
+}
+
+// Volumes is a read-only view of the concatenated volumes of a volume set.
+type Volumes struct {
+	files   []*os.File
+	offsets []int64
+	size    int64
+	closed  bool
+}
+
+// OpenVolumes opens and verifies the volume set at base. An error wrapping
+// ErrInvalidVolume is returned if a volume is missing or does not match the
+// volume manifest.
+func OpenVolumes(base string) (_ *Volumes, err error) {
+	manifest, err := ReadVolumeManifest(base)
+	if err != nil {
+		return nil, err
+	}
+
+	vs := &Volumes{}
+	defer func() {
+		if err != nil {
+			_ = vs.Close()
+		}
+	}()
+	dir := filepath.Dir(base)
+	for i, volume := range manifest.Volumes {
+		path := filepath.Join(dir, volume.Name)
+		if volume.Name != filepath.Base(VolumePath(base, i)) {
+			return nil, fmt.Errorf("%w: unexpected volume %s at position %d in %s", ErrInvalidVolume, volume.Name, i+1, VolumeManifestPath(base))
+		}
+		fp, err := os.Open(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("%w: volume %d of %d is missing: %s", ErrInvalidVolume, i+1, len(manifest.Volumes), path)
+			}
+			return nil, err
+		}
+		vs.files = append(vs.files, fp)
+		if err := verifyVolume(fp, volume); err != nil {
+			return nil, fmt.Errorf("%w: volume %s is corrupted: %v", ErrInvalidVolume, path, err)
+		}
+		vs.offsets = append(vs.offsets, vs.size)
+		vs.size += volume.Size
+	}
+	return vs, nil
+}
+
+// verifyVolume verifies the size and the checksum of a volume.
+func verifyVolume(fp *os.File, volume Volume) error {
+	if err := volume.Digest.Validate(); err != nil {
+		return err
+	}
+	verifier := volume.Digest.Verifier()
+	n, err := io.Copy(verifier, fp)
+	if err != nil {
+		return err
+	}
+	if n != volume.Size {
+		return fmt.Errorf("size %d does not match the expected size %d", n, volume.Size)
+	}
+	if !verifier.Verified() {
+		return errors.New("checksum mismatch")
+	}
+	return nil
+}
+
+// Size returns the total size of the volumes.
+func (vs *Volumes) Size() int64 {
+	return vs.size
+}
+
+// ReadAt reads len(p) bytes at off across the volumes.
+func (vs *Volumes) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	var n int
+	for len(p) > 0 {
+		if off >= vs.size {
+			return n, io.EOF
+		}
+		i := len(vs.offsets) - 1
+		for i > 0 && vs.offsets[i] > off {
+			i--
+		}
+		read, err := vs.files[i].ReadAt(p, off-vs.offsets[i])
+		n += read
+		off += int64(read)
+		p = p[read:]
+		if err != nil && !errors.Is(err, io.EOF) {
+			return n, err
+		}
+		if read == 0 && err != nil {
+			// the volume is shorter than recorded
+			return n, io.ErrUnexpectedEOF
+		}
+	}
+	return n, nil
+}
+
+// Close closes the volumes. Closing the volumes again does nothing.
+func (vs *Volumes) Close() error {
+	if vs.closed {
+		return nil
+	}
+	vs.closed = true
+	var errs []error
+	for _, fp := range vs.files {
+		if err := fp.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	orasio "oras.land/oras/internal/io"
+)
+
+// writeVolumes splits data into volumes of volumeSize at base with writes of
+// chunkSize bytes.
+func writeVolumes(t *testing.T, base string, data []byte, volumeSize int64, chunkSize int) *orasio.VolumeWriter {
+	t.Helper()
+	w, err := orasio.NewVolumeWriter(base, volumeSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for len(data) > 0 {
+		n := min(chunkSize, len(data))
+		if _, err := w.Write(data[:n]); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		data = data[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return w
+}
+
+func TestVolumes(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 25) // 250 bytes
+	tests := []struct {
+		name        string
+		volumeSize  int64
+		chunkSize   int
+		wantVolumes int
+	}{
+		{name: "multiple volumes", volumeSize: 100, chunkSize: 7, wantVolumes: 3},
+		{name: "exact volumes", volumeSize: 125, chunkSize: 250, wantVolumes: 2},
+		{name: "single volume", volumeSize: 1000, chunkSize: 33, wantVolumes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := filepath.Join(t.TempDir(), "backup.tar")
+			w := writeVolumes(t, base, data, tt.volumeSize, tt.chunkSize)
+			if got := len(w.Volumes()); got != tt.wantVolumes {
+				t.Fatalf("Volumes() = %d, want %d", got, tt.wantVolumes)
+			}
+			if _, err := os.Stat(orasio.VolumePath(base, tt.wantVolumes)); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("unexpected extra volume: %v", err)
+			}
+
+			for _, path := range []string{orasio.VolumePath(base, 0), orasio.VolumePath(base, tt.wantVolumes-1), orasio.VolumeManifestPath(base)} {
+				if got, ok := orasio.VolumeBase(path); !ok || got != base {
+					t.Errorf("VolumeBase(%q) = %q, %v, want %q, true", path, got, ok, base)
+				}
+			}
+			manifest, err := orasio.ReadVolumeManifest(base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if manifest.Size() != int64(len(data)) {
+				t.Errorf("VolumeManifest.Size() = %d, want %d", manifest.Size(), len(data))
+			}
+
+			vs, err := orasio.OpenVolumes(base)
+			if err != nil {
+				t.Fatalf("OpenVolumes() error = %v", err)
+			}
+			defer vs.Close()
+			if vs.Size() != int64(len(data)) {
+				t.Errorf("Size() = %d, want %d", vs.Size(), len(data))
+			}
+			got, err := io.ReadAll(io.NewSectionReader(vs, 0, vs.Size()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("volumes content = %q, want %q", got, data)
+			}
+			// read across the boundary of volumes
+			buf := make([]byte, 20)
+			if n, err := vs.ReadAt(buf, tt.volumeSize-10); tt.volumeSize+10 <= int64(len(data)) && (err != nil || n != 20 || !bytes.Equal(buf, data[tt.volumeSize-10:tt.volumeSize+10])) {
+				t.Errorf("ReadAt() = %d, %v, %q", n, err, buf)
+			}
+			if _, err := vs.ReadAt(buf, vs.Size()-5); !errors.Is(err, io.EOF) {
+				t.Errorf("ReadAt() beyond the end error = %v, want %v", err, io.EOF)
+			}
+			for range 2 {
+				if err := vs.Close(); err != nil {
+					t.Errorf("Close() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenVolumes_invalid(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 25)
+
+	t.Run("missing volume", func(t *testing.T) {
+		base := filepath.Join(t.TempDir(), "backup.tar")
+		writeVolumes(t, base, data, 100, 100)
+		if err := os.Remove(orasio.VolumePath(base, 1)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := orasio.OpenVolumes(base); !errors.Is(err, orasio.ErrInvalidVolume) {
+			t.Errorf("OpenVolumes() error = %v, want %v", err, orasio.ErrInvalidVolume)
+		}
+	})
+
+	t.Run("corrupted volume", func(t *testing.T) {
+		base := filepath.Join(t.TempDir(), "backup.tar")
+		writeVolumes(t, base, data, 100, 100)
+		if err := os.WriteFile(orasio.VolumePath(base, 2), bytes.Repeat([]byte("x"), 50), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := orasio.OpenVolumes(base); !errors.Is(err, orasio.ErrInvalidVolume) {
+			t.Errorf("OpenVolumes() error = %v, want %v", err, orasio.ErrInvalidVolume)
+		}
+	})
+
+	t.Run("truncated volume", func(t *testing.T) {
+		base := filepath.Join(t.TempDir(), "backup.tar")
+		writeVolumes(t, base, data, 100, 100)
+		if err := os.WriteFile(orasio.VolumePath(base, 0), data[:99], 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := orasio.OpenVolumes(base); !errors.Is(err, orasio.ErrInvalidVolume) {
+			t.Errorf("OpenVolumes() error = %v, want %v", err, orasio.ErrInvalidVolume)
+		}
+	})
+
+	t.Run("missing manifest", func(t *testing.T) {
+		base := filepath.Join(t.TempDir(), "backup.tar")
+		if _, ok := orasio.VolumeBase(orasio.VolumePath(base, 0)); ok {
+			t.Error("VolumeBase() = true without a volume manifest")
+		}
+		if _, err := orasio.OpenVolumes(base); err == nil {
+			t.Error("OpenVolumes() error = nil, want error")
+		}
+	})
+}
+
+func TestVolumeWriter_Remove(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "backup.tar")
+	w, err := orasio.NewVolumeWriter(base, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(make([]byte, 25)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("volumes are not removed: %v", entries)
+	}
+	if _, err := orasio.NewVolumeWriter(base, 0); err == nil {
+		t.Error("NewVolumeWriter() error = nil, want error")
+	}
+}
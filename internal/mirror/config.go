@@ -0,0 +1,184 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"oras.land/oras-go/v2/registry"
+)
+
+// Config describes the repositories to be mirrored.
+//
+// Example:
+//
+//	destination: registry.internal:5000/mirror
+//	includeReferrers: true
+//	repositories:
+//	  - source: docker.io/library/alpine
+//	    tags: ["3\\.\\d+", "latest"]
+//	    platforms: ["linux/amd64"]
+//	  - source: ghcr.io/oras-project/oras
+//	    destination: registry.internal:5000/tools/oras
+//	    includeReferrers: false
+type Config struct {
+	// Destination is the default destination namespace. The repository path of
+	// a source is appended to it if the repository does not specify its own
+	// destination.
+	Destination string `yaml:"destination"`
+	// Tags is the default list of tag filters.
+	Tags []string `yaml:"tags"`
+	// Platforms is the default list of platform filters.
+	Platforms []string `yaml:"platforms"`
+	// IncludeReferrers is the default for copying referrers.
+	IncludeReferrers bool `yaml:"includeReferrers"`
+	// Repositories is the list of repositories to be mirrored.
+	Repositories []Repository `yaml:"repositories"`
+}
+
+// Repository describes a single source repository to be mirrored.
+type Repository struct {
+	// Source is the source repository in the form of <registry>/<repository>.
+	Source string `yaml:"source"`
+	// Destination is the destination repository in the form of
+	// <registry>/<repository>.
+	Destination string `yaml:"destination"`
+	// Tags is a list of regular expressions. A tag is mirrored if it fully
+	// matches any of them. All tags are mirrored if the list is empty.
+	Tags []string `yaml:"tags"`
+	// Platforms is a list of platforms in the form of
+	// `os[/arch][/variant][:os_version]` to be mirrored.
+	Platforms []string `yaml:"platforms"`
+	// IncludeReferrers indicates whether referrers are mirrored as well.
+	IncludeReferrers *bool `yaml:"includeReferrers"`
+
+	tagFilters []*regexp.Regexp
+}
+
+// LoadConfig reads and validates the mirror configuration from path.
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mirror configuration: %w", err)
+	}
+	return ParseConfig(content)
+}
+
+// ParseConfig parses and validates the mirror configuration in YAML format.
+// Repository level settings are filled with the defaults of the
+// configuration if not specified.
+func ParseConfig(content []byte) (*Config, error) {
+	var cfg Config
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse mirror configuration: %w", err)
+	}
+	if len(cfg.Repositories) == 0 {
+		return nil, errors.New("no repositories specified in the mirror configuration")
+	}
+	cfg.Destination = strings.TrimSuffix(cfg.Destination, "/")
+	for i := range cfg.Repositories {
+		if err := cfg.complete(&cfg.Repositories[i]); err != nil {
+			return nil, fmt.Errorf("invalid repository #%d in the mirror configuration: %w", i+1, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// complete validates repo and fills in defaults.
+func (cfg *Config) complete(repo *Repository) error {
+	src, err := parseRepository(repo.Source)
+	if err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+	repo.Source = src.String()
+	if repo.Destination == "" {
+		if cfg.Destination == "" {
+			return fmt.Errorf("no destination specified for %q", repo.Source)
+		}
+		repo.Destination = cfg.Destination + "/" + src.Repository
+	}
+	dst, err := parseRepository(repo.Destination)
+	if err != nil {
+		return fmt.Errorf("invalid destination: %w", err)
+	}
+	repo.Destination = dst.String()
+	if repo.Source == repo.Destination {
+		return fmt.Errorf("source and destination are the same: %q", repo.Source)
+	}
+
+	if repo.Tags == nil {
+		repo.Tags = cfg.Tags
+	}
+	for _, pattern := range repo.Tags {
+		filter, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid tag filter %q: %w", pattern, err)
+		}
+		repo.tagFilters = append(repo.tagFilters, filter)
+	}
+	if repo.Platforms == nil {
+		repo.Platforms = cfg.Platforms
+	}
+	if repo.IncludeReferrers == nil {
+		repo.IncludeReferrers = &cfg.IncludeReferrers
+	}
+	return nil
+}
+
+// MatchTag returns true if tag should be mirrored.
+func (repo *Repository) MatchTag(tag string) bool {
+	if len(repo.tagFilters) == 0 {
+		return true
+	}
+	for _, filter := range repo.tagFilters {
+		if filter.MatchString(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Settings returns the settings of repo affecting the mirrored content of a
+// tag, so that a tag is mirrored again if they change.
+func (repo *Repository) Settings() string {
+	platforms := slices.Clone(repo.Platforms)
+	slices.Sort(platforms)
+	return fmt.Sprintf("platforms=%s;referrers=%t", strings.Join(platforms, ","), *repo.IncludeReferrers)
+}
+
+// parseRepository parses a repository reference without tag or digest.
+func parseRepository(raw string) (registry.Reference, error) {
+	if raw == "" {
+		return registry.Reference{}, errors.New("repository cannot be empty")
+	}
+	ref, err := registry.ParseReference(raw)
+	if err != nil {
+		return registry.Reference{}, err
+	}
+	if ref.Reference != "" {
+		return registry.Reference{}, fmt.Errorf("tags or digests should not be provided: %q", raw)
+	}
+	return ref, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	content := []byte(`
+destination: localhost:5000/mirror/
+includeReferrers: true
+tags: ["latest"]
+repositories:
+  - source: docker.io/library/alpine
+    tags: ["3\\.\\d+"]
+    platforms: ["linux/amd64"]
+  - source: ghcr.io/oras-project/oras
+    destination: localhost:5000/tools/oras
+    includeReferrers: false
+`)
+	cfg, err := ParseConfig(content)
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if len(cfg.Repositories) != 2 {
+		t.Fatalf("ParseConfig() got %d repositories, want 2", len(cfg.Repositories))
+	}
+
+	alpine := cfg.Repositories[0]
+	if want := "localhost:5000/mirror/library/alpine"; alpine.Destination != want {
+		t.Errorf("destination = %q, want %q", alpine.Destination, want)
+	}
+	if !reflect.DeepEqual(alpine.Platforms, []string{"linux/amd64"}) {
+		t.Errorf("platforms = %v, want [linux/amd64]", alpine.Platforms)
+	}
+	if !*alpine.IncludeReferrers {
+		t.Error("includeReferrers = false, want default true")
+	}
+	for tag, want := range map[string]bool{
+		"3.19":      true,
+		"3.19-rc":   false,
+		"latest":    false,
+		"edge-3.19": false,
+	} {
+		if got := alpine.MatchTag(tag); got != want {
+			t.Errorf("MatchTag(%q) = %v, want %v", tag, got, want)
+		}
+	}
+
+	tools := cfg.Repositories[1]
+	if want := "localhost:5000/tools/oras"; tools.Destination != want {
+		t.Errorf("destination = %q, want %q", tools.Destination, want)
+	}
+	if *tools.IncludeReferrers {
+		t.Error("includeReferrers = true, want false")
+	}
+	if !tools.MatchTag("latest") || tools.MatchTag("v1.0.0") {
+		t.Error("default tag filters are not applied")
+	}
+
+	if got, want := alpine.Settings(), "platforms=linux/amd64;referrers=true"; got != want {
+		t.Errorf("Settings() = %q, want %q", got, want)
+	}
+	if alpine.Settings() == tools.Settings() {
+		t.Error("Settings() should differ for different referrer settings")
+	}
+}
+
+func TestParseConfig_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "no repositories",
+			content: "destination: localhost:5000/mirror",
+		},
+		{
+			name:    "unknown field",
+			content: "repositories:\n  - source: localhost:5000/a\n    destination: localhost:5000/b\n    tag: latest",
+		},
+		{
+			name:    "no destination",
+			content: "repositories:\n  - source: localhost:5000/a",
+		},
+		{
+			name:    "source with tag",
+			content: "destination: localhost:5000/mirror\nrepositories:\n  - source: localhost:5000/a:v1",
+		},
+		{
+			name:    "same source and destination",
+			content: "repositories:\n  - source: localhost:5000/a\n    destination: localhost:5000/a",
+		},
+		{
+			name:    "invalid tag filter",
+			content: "destination: localhost:5000/mirror\nrepositories:\n  - source: localhost:5000/a\n    tags: [\"(\"]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseConfig([]byte(tt.content)); err == nil {
+				t.Error("ParseConfig() error = nil, want error")
+			}
+		})
+	}
+}
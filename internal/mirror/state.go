@@ -0,0 +1,143 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// stateVersion is the version of the state file format.
+const stateVersion = 1
+
+// State records the source digests of the tags that have been mirrored, so
+// that unchanged tags can be skipped in later runs.
+type State struct {
+	// Version is the version of the state file format.
+	Version int `json:"version"`
+	// Repositories maps a destination repository to the states of its
+	// mirrored tags.
+	Repositories map[string]map[string]TagState `json:"repositories"`
+
+	path string
+	lock sync.Mutex
+}
+
+// TagState is the state of a mirrored tag.
+type TagState struct {
+	// Digest is the source digest of the tag.
+	Digest digest.Digest `json:"digest"`
+	// Settings are the settings the tag was mirrored with. See
+	// Repository.Settings.
+	Settings string `json:"settings"`
+}
+
+// LoadState loads the mirror state from path. An empty state is returned if
+// the file does not exist.
+func LoadState(path string) (*State, error) {
+	state := &State{
+		Version:      stateVersion,
+		Repositories: make(map[string]map[string]TagState),
+		path:         path,
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read mirror state: %w", err)
+	}
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(content, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse mirror state %q: %w", path, err)
+	}
+	if header.Version != stateVersion {
+		return nil, fmt.Errorf("unsupported mirror state version %d in %q", header.Version, path)
+	}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("failed to parse mirror state %q: %w", path, err)
+	}
+	if state.Repositories == nil {
+		state.Repositories = make(map[string]map[string]TagState)
+	}
+	return state, nil
+}
+
+// Digest returns the source digest last mirrored for the tag of the
+// destination repository. The digest is not found if the tag was mirrored with
+// different settings.
+func (s *State) Digest(repository, tag, settings string) (digest.Digest, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	tagState, ok := s.Repositories[repository][tag]
+	if !ok || tagState.Settings != settings {
+		return "", false
+	}
+	return tagState.Digest, true
+}
+
+// Update records the source digest mirrored for the tag of the destination
+// repository with settings, and saves the state.
+func (s *State) Update(repository, tag, settings string, dgst digest.Digest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	tags, ok := s.Repositories[repository]
+	if !ok {
+		tags = make(map[string]TagState)
+		s.Repositories[repository] = tags
+	}
+	tags[tag] = TagState{
+		Digest:   dgst,
+		Settings: settings,
+	}
+	return s.save()
+}
+
+// save writes the state to a temporary file and renames it to the state path
+// so that the state file is never left half-written.
+func (s *State) save() error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.path)
+	fp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to save mirror state: %w", err)
+	}
+	tempPath := fp.Name()
+	_, err = fp.Write(content)
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempPath, s.path)
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("failed to save mirror state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mirror.state.json")
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	const settings = "platforms=linux/amd64;referrers=true"
+	if _, ok := state.Digest("localhost:5000/a", "v1", settings); ok {
+		t.Fatal("Digest() of an empty state should not be found")
+	}
+
+	dgst := digest.FromString("foo")
+	if err := state.Update("localhost:5000/a", "v1", settings, dgst); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	reloaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	got, ok := reloaded.Digest("localhost:5000/a", "v1", settings)
+	if !ok || got != dgst {
+		t.Errorf("Digest() = %v, %v, want %v, true", got, ok, dgst)
+	}
+	if _, ok := reloaded.Digest("localhost:5000/a", "v1", "platforms=;referrers=true"); ok {
+		t.Error("Digest() should not be found for changed settings")
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temporary state files are left behind: %v", entries)
+	}
+}
+
+func TestLoadState_invalid(t *testing.T) {
+	dir := t.TempDir()
+	corrupted := filepath.Join(dir, "corrupted.json")
+	if err := os.WriteFile(corrupted, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadState(corrupted); err == nil {
+		t.Error("LoadState() error = nil, want error for corrupted state")
+	}
+	unknown := filepath.Join(dir, "unknown.json")
+	if err := os.WriteFile(unknown, []byte(`{"version":99}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadState(unknown); err == nil {
+		t.Error("LoadState() error = nil, want error for unknown version")
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package promote records promotions of artifacts so that they can be rolled
+// back.
+package promote
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// Action is the action recorded in a journal entry.
+type Action string
+
+const (
+	// ActionPromote indicates a tag is moved to a promoted artifact.
+	ActionPromote Action = "promote"
+	// ActionRollback indicates a tag is moved back to its previous artifact.
+	ActionRollback Action = "rollback"
+)
+
+// Entry is a record of a promotion or a rollback.
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Action     Action    `json:"action"`
+	Repository string    `json:"repository"`
+	Tag        string    `json:"tag"`
+	// Source is the reference of the promoted artifact.
+	Source string `json:"source,omitempty"`
+	// Previous is the digest the tag pointed to before the action. It is empty
+	// if the tag did not exist.
+	Previous digest.Digest `json:"previous,omitempty"`
+	// Digest is the digest the tag points to after the action.
+	Digest digest.Digest `json:"digest"`
+}
+
+// Journal is an append-only log of promotions and rollbacks, stored as a file
+// with one JSON entry per line.
+type Journal struct {
+	path    string
+	entries []Entry
+}
+
+// Open loads the journal at path. An empty journal is returned if the file
+// does not exist.
+func Open(path string) (*Journal, error) {
+	j := &Journal{path: path}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return j, nil
+		}
+		return nil, fmt.Errorf("failed to read promotion journal: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse promotion journal %q: %w", path, err)
+		}
+		j.entries = append(j.entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read promotion journal: %w", err)
+	}
+	return j, nil
+}
+
+// Append appends the entry to the journal file.
+func (j *Journal) Append(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return fmt.Errorf("failed to write promotion journal: %w", err)
+	}
+	fp, err := os.OpenFile(j.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write promotion journal: %w", err)
+	}
+	_, err = fp.Write(append(line, '\n'))
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write promotion journal: %w", err)
+	}
+	j.entries = append(j.entries, entry)
+	return nil
+}
+
+// LastPromotion returns the latest promotion of the tag in the repository that
+// has not been rolled back yet. Each rollback cancels the latest promotion
+// before it, so that repeated rollbacks walk back through the promotions.
+func (j *Journal) LastPromotion(repository, tag string) (Entry, bool) {
+	var promotions []Entry
+	for _, entry := range j.entries {
+		if entry.Repository != repository || entry.Tag != tag {
+			continue
+		}
+		switch entry.Action {
+		case ActionPromote:
+			promotions = append(promotions, entry)
+		case ActionRollback:
+			if len(promotions) > 0 {
+				promotions = promotions[:len(promotions)-1]
+			}
+		}
+	}
+	if len(promotions) == 0 {
+		return Entry{}, false
+	}
+	return promotions[len(promotions)-1], true
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promote
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestJournal_LastPromotion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "promote.journal")
+	j, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, ok := j.LastPromotion("localhost:5000/hello", "stable"); ok {
+		t.Fatal("LastPromotion() of an empty journal should not be found")
+	}
+
+	v1, v2, v3 := digest.FromString("v1"), digest.FromString("v2"), digest.FromString("v3")
+	for _, entry := range []Entry{
+		{Action: ActionPromote, Repository: "localhost:5000/hello", Tag: "stable", Digest: v1},
+		{Action: ActionPromote, Repository: "localhost:5000/hello", Tag: "stable", Previous: v1, Digest: v2},
+		{Action: ActionPromote, Repository: "localhost:5000/hello", Tag: "latest", Digest: v2},
+		{Action: ActionPromote, Repository: "localhost:5000/hello", Tag: "stable", Previous: v2, Digest: v3},
+	} {
+		if err := j.Append(entry); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	// reload from the file
+	if j, err = Open(path); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	got, ok := j.LastPromotion("localhost:5000/hello", "stable")
+	if !ok || got.Digest != v3 || got.Previous != v2 {
+		t.Fatalf("LastPromotion() = %v, %v, want the promotion of %s", got, ok, v3)
+	}
+
+	// each rollback cancels the latest promotion
+	if err := j.Append(Entry{Action: ActionRollback, Repository: "localhost:5000/hello", Tag: "stable", Previous: v3, Digest: v2}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if got, ok = j.LastPromotion("localhost:5000/hello", "stable"); !ok || got.Digest != v2 {
+		t.Fatalf("LastPromotion() = %v, %v, want the promotion of %s", got, ok, v2)
+	}
+	if err := j.Append(Entry{Action: ActionRollback, Repository: "localhost:5000/hello", Tag: "stable", Previous: v2, Digest: v1}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if got, ok = j.LastPromotion("localhost:5000/hello", "stable"); !ok || got.Digest != v1 || got.Previous != "" {
+		t.Fatalf("LastPromotion() = %v, %v, want the first promotion", got, ok)
+	}
+	if got, ok = j.LastPromotion("localhost:5000/hello", "latest"); !ok || got.Digest != v2 {
+		t.Fatalf("LastPromotion() = %v, %v, want the promotion of %s", got, ok, v2)
+	}
+}
+
+func TestOpen_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "promote.journal")
+	if err := os.WriteFile(path, []byte("{\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("Open() should fail for a corrupted journal")
+	}
+}